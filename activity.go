@@ -0,0 +1,178 @@
+package gowithings
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ActivityField is a field of the daily activity summary that can be requested from the API.
+type ActivityField string
+
+const (
+	ActivityFieldSteps         ActivityField = "steps"
+	ActivityFieldDistance      ActivityField = "distance"
+	ActivityFieldElevation     ActivityField = "elevation"
+	ActivityFieldSoft          ActivityField = "soft"
+	ActivityFieldModerate      ActivityField = "moderate"
+	ActivityFieldIntense       ActivityField = "intense"
+	ActivityFieldActive        ActivityField = "active"
+	ActivityFieldCalories      ActivityField = "calories"
+	ActivityFieldTotalCalories ActivityField = "totalcalories"
+	ActivityFieldHRAverage     ActivityField = "hr_average"
+	ActivityFieldHRMin         ActivityField = "hr_min"
+	ActivityFieldHRMax         ActivityField = "hr_max"
+	ActivityFieldHRZone0       ActivityField = "hr_zone_0"
+	ActivityFieldHRZone1       ActivityField = "hr_zone_1"
+	ActivityFieldHRZone2       ActivityField = "hr_zone_2"
+	ActivityFieldHRZone3       ActivityField = "hr_zone_3"
+)
+
+// AllActivityFields is every field of the daily activity summary.
+var AllActivityFields = []ActivityField{
+	ActivityFieldSteps, ActivityFieldDistance, ActivityFieldElevation, ActivityFieldSoft, ActivityFieldModerate,
+	ActivityFieldIntense, ActivityFieldActive, ActivityFieldCalories, ActivityFieldTotalCalories,
+	ActivityFieldHRAverage, ActivityFieldHRMin, ActivityFieldHRMax, ActivityFieldHRZone0, ActivityFieldHRZone1,
+	ActivityFieldHRZone2, ActivityFieldHRZone3,
+}
+
+// joinFields joins the fields into the comma separated list expected by the data_fields parameter.
+func joinFields[T ~string](fields []T) string {
+	s := make([]string, len(fields))
+	for i, f := range fields {
+		s[i] = string(f)
+	}
+	return strings.Join(s, ",")
+}
+
+// ActivityParam is the parameter needed to specify what daily activity summaries to retrieve. Either StartDate and
+// EndDate or LastUpdate must be provided. Only the calendar day of StartDate and EndDate is used.
+type ActivityParam struct {
+	StartDate  time.Time
+	EndDate    time.Time
+	LastUpdate time.Time
+	// Fields selects the fields to return. All fields are returned if none are provided.
+	Fields []ActivityField
+	Offset int
+}
+
+// URLEncode encodes the parameter into the form values of a get activity request.
+func (p ActivityParam) URLEncode() (string, error) {
+	v := url.Values{}
+	v.Add("action", "getactivity")
+
+	switch {
+	case !p.LastUpdate.IsZero():
+		v.Add("lastupdate", strconv.FormatInt(p.LastUpdate.Unix(), 10))
+	case !p.StartDate.IsZero() && !p.EndDate.IsZero():
+		v.Add("startdateymd", p.StartDate.Format(time.DateOnly))
+		v.Add("enddateymd", p.EndDate.Format(time.DateOnly))
+	default:
+		return "", errors.New("either a start and end date or a last update must be provided")
+	}
+
+	fields := p.Fields
+	if len(fields) == 0 {
+		fields = AllActivityFields
+	}
+	v.Add("data_fields", joinFields(fields))
+
+	if p.Offset > 0 {
+		v.Add("offset", strconv.Itoa(p.Offset))
+	}
+
+	return v.Encode(), nil
+}
+
+// ActivityResponse is the raw response of a get activity API request.
+type ActivityResponse struct {
+	Activities []Activity `json:"activities"`
+	More       bool       `json:"more"`
+	Offset     int        `json:"offset"`
+}
+
+// nextOffset implements pagedResponse.
+func (r ActivityResponse) nextOffset() (int, bool) {
+	return r.Offset, r.More && r.Offset != 0
+}
+
+// Activity is the activity summary of a single day. Durations are in seconds, distance and elevation in meters and
+// calories in kilocalories.
+type Activity struct {
+	Date          string  `json:"date"`
+	Timezone      string  `json:"timezone"`
+	DeviceID      string  `json:"deviceid"`
+	HashDeviceID  string  `json:"hash_deviceid"`
+	Brand         int64   `json:"brand"`
+	IsTracker     bool    `json:"is_tracker"`
+	Steps         int64   `json:"steps"`
+	Distance      float64 `json:"distance"`
+	Elevation     float64 `json:"elevation"`
+	Soft          int64   `json:"soft"`
+	Moderate      int64   `json:"moderate"`
+	Intense       int64   `json:"intense"`
+	Active        int64   `json:"active"`
+	Calories      float64 `json:"calories"`
+	TotalCalories float64 `json:"totalcalories"`
+	HRAverage     int64   `json:"hr_average"`
+	HRMin         int64   `json:"hr_min"`
+	HRMax         int64   `json:"hr_max"`
+	HRZone0       int64   `json:"hr_zone_0"`
+	HRZone1       int64   `json:"hr_zone_1"`
+	HRZone2       int64   `json:"hr_zone_2"`
+	HRZone3       int64   `json:"hr_zone_3"`
+}
+
+// Location returns the timezone of the user for the day, falling back to UTC.
+func (a Activity) Location() *time.Location {
+	return resolveLocation(a.Timezone)
+}
+
+// Day returns midnight of the day of the summary in the timezone of the user.
+func (a Activity) Day() (time.Time, error) {
+	return time.ParseInLocation(time.DateOnly, a.Date, a.Location())
+}
+
+// SoftDuration returns the time spent in soft activities.
+func (a Activity) SoftDuration() time.Duration {
+	return time.Duration(a.Soft) * time.Second
+}
+
+// ModerateDuration returns the time spent in moderate activities.
+func (a Activity) ModerateDuration() time.Duration {
+	return time.Duration(a.Moderate) * time.Second
+}
+
+// IntenseDuration returns the time spent in intense activities.
+func (a Activity) IntenseDuration() time.Duration {
+	return time.Duration(a.Intense) * time.Second
+}
+
+// ActiveDuration returns the total time spent active.
+func (a Activity) ActiveDuration() time.Duration {
+	return time.Duration(a.Active) * time.Second
+}
+
+// HRZoneDurations returns the time spent in each of the four heart rate zones, from light to peak.
+func (a Activity) HRZoneDurations() [4]time.Duration {
+	return [4]time.Duration{
+		time.Duration(a.HRZone0) * time.Second,
+		time.Duration(a.HRZone1) * time.Second,
+		time.Duration(a.HRZone2) * time.Second,
+		time.Duration(a.HRZone3) * time.Second,
+	}
+}
+
+// GetActivity returns the daily activity summaries as specified by the request param, iterating over the offset
+// until every summary is retrieved.
+func (c *UserClient) GetActivity(ctx context.Context, param ActivityParam) ([]Activity, error) {
+	return getAllPages(ctx, c, MeasureV2URL, "get activity", param.Offset, func(offset int) (string, error) {
+		param.Offset = offset
+		return param.URLEncode()
+	}, func(resp ActivityResponse) []Activity {
+		return resp.Activities
+	})
+}
@@ -0,0 +1,77 @@
+package gowithings_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/canadyworkshop/gowithings"
+)
+
+func TestActivityParam_URLEncode(t *testing.T) {
+	day := time.Date(2024, 3, 10, 22, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		param   gowithings.ActivityParam
+		want    url.Values
+		wantErr bool
+	}{
+		{
+			name:  "dates",
+			param: gowithings.ActivityParam{StartDate: day, EndDate: day.AddDate(0, 0, 2), Fields: []gowithings.ActivityField{gowithings.ActivityFieldSteps}},
+			want:  url.Values{"action": {"getactivity"}, "startdateymd": {"2024-03-10"}, "enddateymd": {"2024-03-12"}, "data_fields": {"steps"}},
+		},
+		{
+			name:  "last update wins over dates",
+			param: gowithings.ActivityParam{StartDate: day, EndDate: day, LastUpdate: time.Unix(1700000000, 0), Offset: 5, Fields: []gowithings.ActivityField{gowithings.ActivityFieldSteps, gowithings.ActivityFieldCalories}},
+			want:  url.Values{"action": {"getactivity"}, "lastupdate": {"1700000000"}, "offset": {"5"}, "data_fields": {"steps,calories"}},
+		},
+		{
+			name:    "no range",
+			param:   gowithings.ActivityParam{StartDate: day},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := tt.param.URLEncode()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got, want := encoded, tt.want.Encode(); got != want {
+				t.Errorf("got %s, want %s", got, want)
+			}
+		})
+	}
+
+	encoded, err := gowithings.ActivityParam{LastUpdate: day}.URLEncode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	values, _ := url.ParseQuery(encoded)
+	if values.Get("data_fields") == "" {
+		t.Error("all fields should be requested when none are provided")
+	}
+}
+
+func TestUserClient_GetActivity(t *testing.T) {
+	api := pagedAPI(map[string]string{
+		"0": `{"activities":[{"date":"2024-03-10","steps":1200}],"more":true,"offset":1}`,
+		"1": `{"activities":[{"date":"2024-03-11","steps":800}],"more":false,"offset":0}`,
+	})
+	uc := newTestUserClient(t, api)
+
+	activities, err := uc.GetActivity(context.Background(), gowithings.ActivityParam{LastUpdate: time.Unix(1700000000, 0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(activities) != 2 || activities[0].Date != "2024-03-10" || activities[1].Date != "2024-03-11" {
+		t.Errorf("activities = %+v, want both pages in order", activities)
+	}
+	checkOffsets(t, api, "", "1")
+}
@@ -1,5 +1,43 @@
 package gowithings
 
+import "fmt"
+
+// APIError is returned when the API responds with a non zero status. Errors with the same status match with
+// errors.Is regardless of their message.
+type APIError struct {
+	Status  int
+	Message string
+}
+
+// Error returns the status and message of the error.
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("failed with status %d", e.Status)
+	}
+	return fmt.Sprintf("failed with status %d: %s", e.Status, e.Message)
+}
+
+// Is reports whether the target is an APIError with the same status.
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	return ok && t.Status == e.Status
+}
+
+// Errors returned by the notify service.
+var (
+	ErrInvalidCallbackURL   = &APIError{Status: 293, Message: "the callback URL is either absent or incorrect"}
+	ErrSubscriptionNotFound = &APIError{Status: 294, Message: "no such subscription could be found"}
+	ErrInvalidComment       = &APIError{Status: 304, Message: "the comment is either absent or incorrect"}
+	ErrTooManySubscriptions = &APIError{Status: 305, Message: "too many notifications are already set"}
+	ErrCallbackUnreachable  = &APIError{Status: 343, Message: "the callback URL could not be reached"}
+)
+
+// Errors returned by the services signed with the signature v2 protocol.
+var (
+	ErrInvalidNonce     = &APIError{Status: 2553, Message: "the nonce is invalid or was already used"}
+	ErrInvalidSignature = &APIError{Status: 2555, Message: "the signature is invalid"}
+)
+
 //
 //var errorCodes = map[int]string{
 //0: "Operation was successful",
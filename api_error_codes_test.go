@@ -0,0 +1,25 @@
+package gowithings_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/canadyworkshop/gowithings"
+)
+
+func TestAPIError_Is(t *testing.T) {
+	err := fmt.Errorf("failed to subscribe: %w", &gowithings.APIError{Status: 343, Message: "Wrong notification callback url"})
+
+	if !errors.Is(err, gowithings.ErrCallbackUnreachable) {
+		t.Error("errors.Is(err, ErrCallbackUnreachable) = false, want true")
+	}
+	if errors.Is(err, gowithings.ErrInvalidCallbackURL) {
+		t.Error("errors.Is(err, ErrInvalidCallbackURL) = true, want false")
+	}
+
+	var apiErr *gowithings.APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != 343 {
+		t.Errorf("errors.As did not find the status 343 error in %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import "time"
 type RequestTokenResponse struct {
 	Status int          `json:"status"`
 	Body   RequestToken `json:"body"`
+	Error  string       `json:"error"`
 }
 
 type RequestToken struct {
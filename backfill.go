@@ -0,0 +1,164 @@
+package gowithings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBackfillWindow is the size of the time windows a backfill is split into when none is provided.
+	DefaultBackfillWindow = 90 * 24 * time.Hour
+	// DefaultBackfillWorkers is the number of windows fetched concurrently when no worker count is provided.
+	DefaultBackfillWorkers = 4
+)
+
+// BackfillParam is the parameter needed to backfill the measures of a user over a long date range.
+type BackfillParam struct {
+	// GetMeasureParam selects the measures to fetch. StartDate and EndDate are required, LastUpdate and Offset are
+	// ignored.
+	GetMeasureParam
+
+	// Window is the size of the time windows the range is split into. Defaults to DefaultBackfillWindow.
+	Window time.Duration
+	// Workers is the maximum number of windows fetched concurrently. Requests of all the workers still share the
+	// rate limit of the client. Defaults to DefaultBackfillWorkers.
+	Workers int
+	// Progress, when set, is called after each window completes. Calls are never made concurrently.
+	Progress func(BackfillProgress)
+}
+
+// BackfillProgress reports the progress of a backfill.
+type BackfillProgress struct {
+	// WindowStart and WindowEnd are the bounds of the window that just completed.
+	WindowStart time.Time
+	WindowEnd   time.Time
+	// WindowsDone is the number of windows completed so far out of WindowsTotal.
+	WindowsDone  int
+	WindowsTotal int
+	// Groups is the number of groups fetched so far, before deduplication.
+	Groups int
+}
+
+// windows splits the date range of the parameter into windows that do not overlap.
+func (p BackfillParam) windows() []timeWindow {
+	size := p.Window
+	if size <= 0 {
+		size = DefaultBackfillWindow
+	}
+	return splitTimeRange(p.StartDate, p.EndDate, size)
+}
+
+// mergeMeasureGroups removes duplicate groups by group ID and sorts the remaining groups by date.
+func mergeMeasureGroups(groups []MeasureGroup) []MeasureGroup {
+	seen := make(map[int64]bool, len(groups))
+	merged := make([]MeasureGroup, 0, len(groups))
+	for _, mg := range groups {
+		if seen[mg.GroupID] {
+			continue
+		}
+		seen[mg.GroupID] = true
+		merged = append(merged, mg)
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		if merged[i].Date != merged[j].Date {
+			return merged[i].Date < merged[j].Date
+		}
+		return merged[i].GroupID < merged[j].GroupID
+	})
+	return merged
+}
+
+// Backfill fetches every measure between StartDate and EndDate by splitting the range into windows that are fetched
+// concurrently. Groups are deduplicated by group ID and returned in date order. The first window to fail cancels the
+// remaining windows and its error is returned.
+func (c *UserClient) Backfill(ctx context.Context, param BackfillParam) ([]MeasureGroup, error) {
+	if param.StartDate.IsZero() || param.EndDate.IsZero() {
+		return nil, errors.New("backfill requires a start and end date")
+	}
+	if !param.StartDate.Before(param.EndDate) {
+		return nil, errors.New("backfill start date must be before end date")
+	}
+
+	workers := param.Workers
+	if workers <= 0 {
+		workers = DefaultBackfillWorkers
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	windows := param.windows()
+	jobs := make(chan timeWindow)
+
+	var (
+		mu       sync.Mutex
+		groups   = make([]MeasureGroup, 0)
+		done     int
+		firstErr error
+		wg       sync.WaitGroup
+	)
+
+	for i := 0; i < workers && i < len(windows); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for w := range jobs {
+				windowParam := param.GetMeasureParam
+				windowParam.StartDate = w.start
+				windowParam.EndDate = w.end
+				windowParam.LastUpdate = time.Time{}
+				windowParam.Offset = 0
+
+				result, err := c.GetAllMeasures(ctx, windowParam)
+
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = fmt.Errorf("failed to backfill window %s to %s: %w",
+							w.start.Format(time.RFC3339), w.end.Format(time.RFC3339), err)
+						cancel()
+					}
+					mu.Unlock()
+					continue
+				}
+				groups = append(groups, result...)
+				done++
+				if param.Progress != nil {
+					param.Progress(BackfillProgress{
+						WindowStart:  w.start,
+						WindowEnd:    w.end,
+						WindowsDone:  done,
+						WindowsTotal: len(windows),
+						Groups:       len(groups),
+					})
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for _, w := range windows {
+		select {
+		case <-ctx.Done():
+			break feed
+		case jobs <- w:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return mergeMeasureGroups(groups), nil
+}
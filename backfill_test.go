@@ -0,0 +1,127 @@
+package gowithings_test
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/canadyworkshop/gowithings"
+)
+
+func TestBackfillParam_Windows(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	param := gowithings.BackfillParam{Window: 10 * 24 * time.Hour}
+	param.StartDate = start
+	param.EndDate = start.Add(25 * 24 * time.Hour)
+
+	windows := gowithings.BackfillWindows(param)
+	if len(windows) != 3 {
+		t.Fatalf("got %d windows, want 3", len(windows))
+	}
+	for i, w := range windows {
+		if i > 0 && w[0] != windows[i-1][1].Add(time.Second) {
+			t.Errorf("window %d starts at %v, want one second after %v", i, w[0], windows[i-1][1])
+		}
+		if w[1].Sub(w[0]) >= param.Window {
+			t.Errorf("window %d is %v long, want less than %v", i, w[1].Sub(w[0]), param.Window)
+		}
+	}
+	if windows[0][0] != start || windows[2][1] != param.EndDate {
+		t.Errorf("windows cover %v to %v, want %v to %v", windows[0][0], windows[2][1], start, param.EndDate)
+	}
+
+	// The default window covers short ranges in a single window.
+	param.Window = 0
+	if windows := gowithings.BackfillWindows(param); len(windows) != 1 {
+		t.Errorf("got %d default windows, want 1", len(windows))
+	}
+}
+
+func TestMergeMeasureGroups(t *testing.T) {
+	groups := []gowithings.MeasureGroup{
+		{GroupID: 3, Date: 300},
+		{GroupID: 1, Date: 100},
+		{GroupID: 2, Date: 100},
+		{GroupID: 3, Date: 300},
+		{GroupID: 4, Date: 200},
+	}
+
+	merged := gowithings.MergeMeasureGroups(groups)
+	want := []int64{1, 2, 4, 3}
+	if len(merged) != len(want) {
+		t.Fatalf("got %d groups, want %d", len(merged), len(want))
+	}
+	for i, id := range want {
+		if merged[i].GroupID != id {
+			t.Errorf("group %d = %d, want %d", i, merged[i].GroupID, id)
+		}
+	}
+}
+
+func TestUserClient_Backfill(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := func(n int) int64 { return start.Add(time.Duration(n) * 24 * time.Hour).Unix() }
+	group := func(id int64, date int64) string {
+		return fmt.Sprintf(`{"grpid":%d,"date":%d,"category":1,"measures":[{"value":70000,"type":1,"unit":-3}]}`, id, date)
+	}
+
+	// The first window is returned over two pages and the last one repeats a group of the second window.
+	api := &fakeAPI{respond: func(form url.Values) string {
+		switch form.Get("startdate") {
+		case strconv.FormatInt(start.Unix(), 10):
+			if form.Get("offset") == "" {
+				return fmt.Sprintf(`{"measuregrps":[%s],"more":1,"offset":1}`, group(2, day(5)))
+			}
+			return fmt.Sprintf(`{"measuregrps":[%s],"more":0,"offset":0}`, group(1, day(1)))
+		case strconv.FormatInt(day(20), 10):
+			return fmt.Sprintf(`{"measuregrps":[%s,%s]}`, group(3, day(15)), group(4, day(22)))
+		default:
+			return fmt.Sprintf(`{"measuregrps":[%s]}`, group(3, day(15)))
+		}
+	}}
+	uc := newTestUserClient(t, api)
+
+	var (
+		mu       sync.Mutex
+		progress []gowithings.BackfillProgress
+	)
+	param := gowithings.BackfillParam{
+		Window: 10 * 24 * time.Hour,
+		Progress: func(p gowithings.BackfillProgress) {
+			mu.Lock()
+			defer mu.Unlock()
+			progress = append(progress, p)
+		},
+	}
+	param.MeasureTypes = []string{"1"}
+	param.StartDate = start
+	param.EndDate = start.Add(25 * 24 * time.Hour)
+
+	groups, err := uc.Backfill(context.Background(), param)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := len(api.Requests()); got != 4 {
+		t.Errorf("got %d requests, want 4 for three windows with one paged", got)
+	}
+	want := []int64{1, 2, 3, 4}
+	if len(groups) != len(want) {
+		t.Fatalf("got %d groups, want %d", len(groups), len(want))
+	}
+	for i, id := range want {
+		if groups[i].GroupID != id {
+			t.Errorf("group %d = %d, want %d", i, groups[i].GroupID, id)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(progress) != 3 || progress[2].WindowsDone != 3 || progress[2].WindowsTotal != 3 {
+		t.Errorf("progress = %+v, want three windows reported", progress)
+	}
+}
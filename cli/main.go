@@ -39,5 +39,5 @@ func main() {
 		log.Fatal(err)
 	}
 
-	fmt.Printf("Refresh Token: %s\n, Expire: %d\n", token.Body.RefreshToken, token.Body.RefreshTokenCreationDate)
+	fmt.Printf("Refresh Token: %s\n, Expire: %v\n", token.Body.RefreshToken, token.Body.RefreshTokenCreationDate)
 }
@@ -11,15 +11,24 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 )
 
 const (
-	DefaultScopes    = "user.info,user.metrics,user.activity"
-	AuthorizationURL = "https://account.withings.com/oauth2_user/authorize2"
-	RequestTokenURL  = "https://wbsapi.withings.net/v2/oauth2"
-	SignatureURL     = "https://wbsapi.withings.net/v2/signature"
+	DefaultScopes     = "user.info,user.metrics,user.activity"
+	AuthorizationURL  = "https://account.withings.com/oauth2_user/authorize2"
+	RequestTokenURL   = "https://wbsapi.withings.net/v2/oauth2"
+	SignatureURL      = "https://wbsapi.withings.net/v2/signature"
+	MeasureURL        = "https://wbsapi.withings.net/measure"
+	MeasureV2URL      = "https://wbsapi.withings.net/v2/measure"
+	SleepV2URL        = "https://wbsapi.withings.net/v2/sleep"
+	HeartV2URL        = "https://wbsapi.withings.net/v2/heart"
+	StethoV2URL       = "https://wbsapi.withings.net/v2/stetho"
+	UserV2URL         = "https://wbsapi.withings.net/v2/user"
+	NotifyURL         = "https://wbsapi.withings.net/notify"
+	DropshipmentV2URL = "https://wbsapi.withings.net/v2/dropshipment"
 )
 
 // genStateValue generates a random 64 byte string that is URL encoded to be used
@@ -47,19 +56,34 @@ type Config struct {
 	ClientID     string
 	ClientSecret string
 	RedirectURL  string
+
+	// HTTPClient is used for every request made by the client and the user clients created from it. Defaults to a
+	// new http.Client.
+	HTTPClient *http.Client
+
+	// RequestsPerMinute limits the number of API requests made per minute by all the user clients created from the
+	// client. Withings allows 120 requests per minute per application. Zero disables the limit.
+	RequestsPerMinute int
 }
 
 // Client represents a client of the Withings API.
 type Client struct {
 	config     Config
 	httpClient *http.Client
+	limiter    *rateLimiter
 }
 
 // NewClient creates a new client based on the configuration provided.
 func NewClient(config Config) *Client {
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+
 	client := &Client{
 		config:     config,
-		httpClient: &http.Client{},
+		httpClient: httpClient,
+		limiter:    newRateLimiter(config.RequestsPerMinute),
 	}
 
 	return client
@@ -127,7 +151,8 @@ func (client *Client) NewUserClient(token RequestToken) *UserClient {
 		clientID:     client.config.ClientID,
 		clientSecret: client.config.ClientSecret,
 		token:        token,
-		httpClient:   &http.Client{},
+		httpClient:   client.httpClient,
+		limiter:      client.limiter,
 	}
 }
 
@@ -149,7 +174,8 @@ func (client *Client) NewUserClientFromRefreshToken(ctx context.Context, refresh
 			AccessTokenCreationDate:  time.Time{},
 			RefreshTokenCreationDate: refreshTokenCreationDate,
 		},
-		httpClient: &http.Client{},
+		httpClient: client.httpClient,
+		limiter:    client.limiter,
 	}
 
 	err := c.refreshToken(ctx)
@@ -160,60 +186,36 @@ func (client *Client) NewUserClientFromRefreshToken(ctx context.Context, refresh
 	return &c, nil
 }
 
-// DemoUser generates a UserClient for the demo user. This is primarily used for testing.
-func (client *Client) DemoUser(ctx context.Context) (*UserClient, error) {
-	reqTokenResp := RequestTokenResponse{}
-
-	// Obtain nonce.
-	nonce, err := client.getNonce(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get nonce: %s", err)
-	}
-
-	signatureStr := genHMACSHA256String(client.config.ClientSecret, fmt.Sprintf("%s,%s,%s", "getdemoaccess", client.config.ClientID, nonce))
-
-	req, err := http.NewRequest(http.MethodPost, RequestTokenURL, nil)
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %s", err)
-	}
-
-	reqQuery := req.URL.Query()
-	reqQuery.Add("action", "getdemoaccess")
-	reqQuery.Add("client_id", client.config.ClientID)
-	reqQuery.Add("nonce", nonce)
-	reqQuery.Add("signature", signatureStr)
-	reqQuery.Add("scope_oauth2", DefaultScopes)
-	req.URL.RawQuery = reqQuery.Encode()
-
-	createdAt := time.Now()
-	resp, err := client.httpClient.Do(req.WithContext(ctx))
-	if err != nil {
-		return nil, fmt.Errorf("failed to request token: %s", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
+// NewUserClientFromCode exchanges the authorization code provided for a token and creates a user client for it.
+func (client *Client) NewUserClientFromCode(ctx context.Context, code string) (*UserClient, error) {
+	resp, err := client.RequestToken(ctx, code)
 	if err != nil {
 		return nil, err
 	}
-
-	err = json.Unmarshal(body, &reqTokenResp)
-	if err != nil {
-		return nil, err
+	if resp.Status != 0 {
+		return nil, &APIError{Status: resp.Status, Message: resp.Error}
 	}
-	reqTokenResp.Body.AccessTokenCreationDate = createdAt
-	reqTokenResp.Body.RefreshTokenCreationDate = createdAt
 
-	uc := client.NewUserClient(reqTokenResp.Body)
+	return client.NewUserClient(resp.Body), nil
+}
+
+// DemoUser generates a UserClient for the demo user. This is primarily used for testing.
+func (client *Client) DemoUser(ctx context.Context) (*UserClient, error) {
+	v := url.Values{}
+	v.Add("scope_oauth2", DefaultScopes)
 
-	if reqTokenResp.Status != 0 {
-		return nil, fmt.Errorf("failed with status %d", reqTokenResp.Status)
+	token := RequestToken{}
+	createdAt := time.Now()
+	if err := client.SignedRequest(ctx, RequestTokenURL, "getdemoaccess", v, &token); err != nil {
+		return nil, fmt.Errorf("failed to request token: %w", err)
 	}
+	token.AccessTokenCreationDate = createdAt
+	token.RefreshTokenCreationDate = createdAt
 
-	return uc, nil
+	return client.NewUserClient(token), nil
 }
 
+// Deprecated: SignedRequest fetches the nonce of signed requests.
 type NonceRequestWrapper struct {
 	Status int          `json:"status"`
 	Body   NonceRequest `json:"body"`
@@ -225,37 +227,18 @@ type NonceRequest struct {
 
 // getNonce retrieves a nonce from the withigns API.
 func (client *Client) getNonce(ctx context.Context) (string, error) {
-	req, err := http.NewRequest(http.MethodPost, SignatureURL, nil)
-
 	ts := strconv.FormatInt(time.Now().Unix(), 10)
-	signatureStr := genHMACSHA256String(client.config.ClientSecret, fmt.Sprintf("%s,%s,%s", "getnonce", client.config.ClientID, ts))
 
-	reqQuery := req.URL.Query()
-	reqQuery.Add("action", "getnonce")
-	reqQuery.Add("client_id", client.config.ClientID)
-	reqQuery.Add("timestamp", ts)
-	reqQuery.Add("signature", signatureStr)
-	req.URL.RawQuery = reqQuery.Encode()
-
-	resp, err := client.httpClient.Do(req.WithContext(ctx))
-	if err != nil {
-		return "", fmt.Errorf("nonce request failed: %s", err)
-	}
-	defer resp.Body.Close()
+	v := url.Values{}
+	v.Add("action", "getnonce")
+	v.Add("client_id", client.config.ClientID)
+	v.Add("timestamp", ts)
+	v.Add("signature", client.sign("getnonce", ts))
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
-	}
-
-	reqResp := NonceRequestWrapper{}
-	err = json.Unmarshal(body, &reqResp)
-	if err != nil {
-		return "", err
-	}
-	if reqResp.Status != 0 {
-		return "", fmt.Errorf("failed with status %d", reqResp.Status)
+	nonce := NonceRequest{}
+	if err := client.postForm(ctx, SignatureURL, v, &nonce); err != nil {
+		return "", fmt.Errorf("nonce request failed: %w", err)
 	}
 
-	return reqResp.Body.Nonce, nil
+	return nonce.Nonce, nil
 }
@@ -0,0 +1,116 @@
+package gowithings_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/canadyworkshop/gowithings"
+)
+
+// rewriteTransport sends every request to the target server whatever the API URL it was made for.
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// newTestClient creates a client sending every request to the handler provided.
+func newTestClient(t *testing.T, config gowithings.Config, handler http.Handler) *gowithings.Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	target, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config.HTTPClient = &http.Client{Transport: rewriteTransport{target: target}}
+	return gowithings.NewClient(config)
+}
+
+// newTestUserClient creates a user client with a valid token sending every request to the handler provided.
+func newTestUserClient(t *testing.T, handler http.Handler) *gowithings.UserClient {
+	t.Helper()
+
+	now := time.Now()
+	return newTestClient(t, gowithings.Config{}, handler).NewUserClient(gowithings.RequestToken{
+		AccessToken:              "access",
+		RefreshToken:             "refresh",
+		ExpiresIn:                3600,
+		AccessTokenCreationDate:  now,
+		RefreshTokenCreationDate: now,
+	})
+}
+
+// fakeAPI answers every request with the body returned by respond for its form values, wrapped in the API envelope,
+// and records the form values of the requests.
+type fakeAPI struct {
+	respond  func(form url.Values) string
+	requests []url.Values
+	sync.Mutex
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// The form is parsed from the body directly since not every request sets the form content type.
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	f.Lock()
+	f.requests = append(f.requests, form)
+	f.Unlock()
+
+	fmt.Fprintf(w, `{"status":0,"body":%s}`, f.respond(form))
+}
+
+// Requests returns the form values of the requests received so far.
+func (f *fakeAPI) Requests() []url.Values {
+	f.Lock()
+	defer f.Unlock()
+	return append([]url.Values(nil), f.requests...)
+}
+
+// pagedAPI answers each request with the page of the offset it requests, the first page being the one of offset 0.
+func pagedAPI(pages map[string]string) *fakeAPI {
+	return &fakeAPI{respond: func(form url.Values) string {
+		offset := form.Get("offset")
+		if offset == "" {
+			offset = "0"
+		}
+		return pages[offset]
+	}}
+}
+
+// checkOffsets checks that the requests asked for the offsets provided, in order.
+func checkOffsets(t *testing.T, api *fakeAPI, want ...string) {
+	t.Helper()
+
+	requests := api.Requests()
+	if len(requests) != len(want) {
+		t.Fatalf("got %d requests, want %d", len(requests), len(want))
+	}
+	for i, offset := range want {
+		if got := requests[i].Get("offset"); got != offset {
+			t.Errorf("request %d offset = %q, want %q", i, got, offset)
+		}
+	}
+}
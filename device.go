@@ -0,0 +1,177 @@
+package gowithings
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// DeviceModel is the model of a Withings device as reported by its model ID.
+type DeviceModel int64
+
+const (
+	DeviceModelWBS01             DeviceModel = 1
+	DeviceModelWS30              DeviceModel = 2
+	DeviceModelKidScale          DeviceModel = 3
+	DeviceModelSmartBodyAnalyzer DeviceModel = 4
+	DeviceModelBodyPlus          DeviceModel = 5
+	DeviceModelBodyCardio        DeviceModel = 6
+	DeviceModelBody              DeviceModel = 7
+	DeviceModelBodyScan          DeviceModel = 10
+	DeviceModelWBS10             DeviceModel = 11
+	DeviceModelWBS11             DeviceModel = 12
+	DeviceModelSmartBabyMonitor  DeviceModel = 21
+	DeviceModelHome              DeviceModel = 22
+	DeviceModelBPMV1             DeviceModel = 41
+	DeviceModelBPMV2             DeviceModel = 42
+	DeviceModelBPMV3             DeviceModel = 43
+	DeviceModelBPMCore           DeviceModel = 44
+	DeviceModelBPMConnect        DeviceModel = 45
+	DeviceModelBPMConnectPro     DeviceModel = 46
+	DeviceModelPulse             DeviceModel = 51
+	DeviceModelActivite          DeviceModel = 52
+	DeviceModelActivitePopSteel  DeviceModel = 53
+	DeviceModelGo                DeviceModel = 54
+	DeviceModelSteelHR           DeviceModel = 55
+	DeviceModelPulseHR           DeviceModel = 58
+	DeviceModelSteelHRSport      DeviceModel = 59
+	DeviceModelAuraDock          DeviceModel = 60
+	DeviceModelAuraSensor        DeviceModel = 61
+	DeviceModelAuraDockV2        DeviceModel = 62
+	DeviceModelSleepAnalyzer     DeviceModel = 63
+	DeviceModelThermo            DeviceModel = 70
+	DeviceModelMove              DeviceModel = 90
+	DeviceModelMoveECG           DeviceModel = 91
+	DeviceModelScanWatch         DeviceModel = 93
+)
+
+var deviceModelNames = map[DeviceModel]string{
+	DeviceModelWBS01:             "Withings WBS01",
+	DeviceModelWS30:              "WS30",
+	DeviceModelKidScale:          "Kid Scale",
+	DeviceModelSmartBodyAnalyzer: "Smart Body Analyzer",
+	DeviceModelBodyPlus:          "Body+",
+	DeviceModelBodyCardio:        "Body Cardio",
+	DeviceModelBody:              "Body",
+	DeviceModelBodyScan:          "Body Scan",
+	DeviceModelWBS10:             "WBS10",
+	DeviceModelWBS11:             "WBS11",
+	DeviceModelSmartBabyMonitor:  "Smart Baby Monitor",
+	DeviceModelHome:              "Withings Home",
+	DeviceModelBPMV1:             "Withings Blood Pressure Monitor V1",
+	DeviceModelBPMV2:             "Withings Blood Pressure Monitor V2",
+	DeviceModelBPMV3:             "Withings Blood Pressure Monitor V3",
+	DeviceModelBPMCore:           "BPM Core",
+	DeviceModelBPMConnect:        "BPM Connect",
+	DeviceModelBPMConnectPro:     "BPM Connect Pro",
+	DeviceModelPulse:             "Pulse",
+	DeviceModelActivite:          "Activite",
+	DeviceModelActivitePopSteel:  "Activite (Pop, Steel)",
+	DeviceModelGo:                "Withings Go",
+	DeviceModelSteelHR:           "Activite Steel HR",
+	DeviceModelPulseHR:           "Pulse HR",
+	DeviceModelSteelHRSport:      "Activite Steel HR Sport Edition",
+	DeviceModelAuraDock:          "Aura Dock",
+	DeviceModelAuraSensor:        "Aura Sensor",
+	DeviceModelAuraDockV2:        "Aura Dock",
+	DeviceModelSleepAnalyzer:     "Sleep Analyzer",
+	DeviceModelThermo:            "Thermo",
+	DeviceModelMove:              "Move",
+	DeviceModelMoveECG:           "Move ECG",
+	DeviceModelScanWatch:         "ScanWatch",
+}
+
+// String returns the name of the model or its numeric value if it is unknown.
+func (m DeviceModel) String() string {
+	if name, ok := deviceModelNames[m]; ok {
+		return name
+	}
+	return fmt.Sprintf("DeviceModel(%d)", int64(m))
+}
+
+// IsKnown reports whether the model is one the library knows the name of.
+func (m DeviceModel) IsKnown() bool {
+	_, ok := deviceModelNames[m]
+	return ok
+}
+
+// BatteryLevel is the battery level of a device as reported by the API.
+type BatteryLevel string
+
+const (
+	BatteryLevelHigh   BatteryLevel = "high"
+	BatteryLevelMedium BatteryLevel = "medium"
+	BatteryLevelLow    BatteryLevel = "low"
+)
+
+// IsLow reports whether the battery of the device needs replacing or charging soon.
+func (b BatteryLevel) IsLow() bool {
+	return b == BatteryLevelLow
+}
+
+// devicesResponse is the raw response of a get device API request.
+type devicesResponse struct {
+	Devices []Device `json:"devices"`
+}
+
+// Device is a device of the user.
+type Device struct {
+	// Type is the kind of device, such as Scale or Blood Pressure Monitor.
+	Type string `json:"type"`
+	// ModelName is the name of the model as reported by the API.
+	ModelName        string       `json:"model"`
+	Model            DeviceModel  `json:"model_id"`
+	Battery          BatteryLevel `json:"battery"`
+	DeviceID         string       `json:"deviceid"`
+	HashDeviceID     string       `json:"hash_deviceid"`
+	MACAddress       string       `json:"mac_address"`
+	Firmware         string       `json:"fw"`
+	Timezone         string       `json:"timezone"`
+	FirstSessionDate int64        `json:"first_session_date"`
+	LastSessionDate  int64        `json:"last_session_date"`
+}
+
+// Location returns the timezone of the device, falling back to UTC.
+func (d Device) Location() *time.Location {
+	return resolveLocation(d.Timezone)
+}
+
+// LastSession returns the last time the device synchronised with Withings in the timezone of the device.
+func (d Device) LastSession() time.Time {
+	return time.Unix(d.LastSessionDate, 0).In(d.Location())
+}
+
+// Devices is the set of devices of a user.
+type Devices []Device
+
+// Find returns the device matching the device ID or hash device ID provided.
+func (d Devices) Find(deviceID, hashDeviceID string) (Device, bool) {
+	for _, device := range d {
+		if hashDeviceID != "" && device.HashDeviceID == hashDeviceID {
+			return device, true
+		}
+		if deviceID != "" && (device.DeviceID == deviceID || device.HashDeviceID == deviceID) {
+			return device, true
+		}
+	}
+	return Device{}, false
+}
+
+// ForGroup returns the device that took the measures of the group.
+func (d Devices) ForGroup(mg MeasureGroup) (Device, bool) {
+	return d.Find(mg.DeviceID, mg.HashDeviceID)
+}
+
+// GetDevices returns the devices linked to the user.
+func (c *UserClient) GetDevices(ctx context.Context) (Devices, error) {
+	v := url.Values{}
+	v.Add("action", "getdevice")
+
+	resp := devicesResponse{}
+	if _, err := c.post(ctx, UserV2URL, v.Encode(), &resp); err != nil {
+		return nil, fmt.Errorf("failed to get devices: %w", err)
+	}
+
+	return resp.Devices, nil
+}
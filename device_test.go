@@ -0,0 +1,40 @@
+package gowithings_test
+
+import (
+	"testing"
+
+	"github.com/canadyworkshop/gowithings"
+)
+
+func TestDevices_Find(t *testing.T) {
+	devices := gowithings.Devices{
+		{Type: "Scale", Model: gowithings.DeviceModelBodyCardio, DeviceID: "d1", HashDeviceID: "h1"},
+		{Type: "Watch", Model: gowithings.DeviceModelScanWatch, DeviceID: "d2", HashDeviceID: "h2"},
+	}
+
+	tests := []struct {
+		name         string
+		deviceID     string
+		hashDeviceID string
+		want         string
+		found        bool
+	}{
+		{"by device ID", "d2", "", "d2", true},
+		{"by hash device ID", "", "h1", "d1", true},
+		{"hash in device ID", "h2", "", "d2", true},
+		{"unknown", "d9", "h9", "", false},
+		{"empty", "", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device, ok := devices.Find(tt.deviceID, tt.hashDeviceID)
+			if ok != tt.found || device.DeviceID != tt.want {
+				t.Errorf("Find(%q, %q) = %q, %v, want %q, %v", tt.deviceID, tt.hashDeviceID, device.DeviceID, ok, tt.want, tt.found)
+			}
+		})
+	}
+
+	if device, ok := devices.ForGroup(gowithings.MeasureGroup{HashDeviceID: "h1"}); !ok || device.Model != gowithings.DeviceModelBodyCardio {
+		t.Errorf("ForGroup() = %v, %v, want the Body Cardio", device, ok)
+	}
+}
@@ -0,0 +1,236 @@
+package gowithings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// OrderStatus is the fulfilment status of a dropshipment order.
+type OrderStatus string
+
+const (
+	OrderStatusCreated             OrderStatus = "CREATED"
+	OrderStatusOpen                OrderStatus = "OPEN"
+	OrderStatusAddressVerification OrderStatus = "ADDRESS VERIFICATION"
+	OrderStatusToShip              OrderStatus = "TO SHIP"
+	OrderStatusBackhold            OrderStatus = "BACKHOLD"
+	OrderStatusShipped             OrderStatus = "SHIPPED"
+	OrderStatusDelivered           OrderStatus = "DELIVERED"
+	OrderStatusTrashed             OrderStatus = "TRASHED"
+	OrderStatusFailed              OrderStatus = "FAILED"
+)
+
+var orderStatuses = map[OrderStatus]bool{
+	OrderStatusCreated:             true,
+	OrderStatusOpen:                true,
+	OrderStatusAddressVerification: true,
+	OrderStatusToShip:              true,
+	OrderStatusBackhold:            true,
+	OrderStatusShipped:             true,
+	OrderStatusDelivered:           true,
+	OrderStatusTrashed:             true,
+	OrderStatusFailed:              true,
+}
+
+// IsKnown reports whether the status is one of the values documented by Withings.
+func (s OrderStatus) IsKnown() bool {
+	return orderStatuses[s]
+}
+
+// IsFinal reports whether the order will not change status anymore.
+func (s OrderStatus) IsFinal() bool {
+	return s == OrderStatusDelivered || s == OrderStatusTrashed || s == OrderStatusFailed
+}
+
+// IsShipped reports whether the order left the warehouse.
+func (s OrderStatus) IsShipped() bool {
+	return s == OrderStatusShipped || s == OrderStatusDelivered
+}
+
+// Address is the shipping address of an order. Country is the ISO 3166-1 alpha-2 code of the country.
+type Address struct {
+	Name        string `json:"name"`
+	CompanyName string `json:"company_name,omitempty"`
+	Email       string `json:"email"`
+	Telephone   string `json:"telephone,omitempty"`
+	Address1    string `json:"address1"`
+	Address2    string `json:"address2,omitempty"`
+	City        string `json:"city"`
+	Zip         string `json:"zip"`
+	State       string `json:"state,omitempty"`
+	Country     string `json:"country"`
+}
+
+// OrderProduct is a product of an order. EAN is the SKU of the product as listed in the Withings partner catalog.
+type OrderProduct struct {
+	EAN      string `json:"ean"`
+	Quantity int    `json:"quantity"`
+}
+
+// NewOrder is an order to create.
+type NewOrder struct {
+	// CustomerRefID is the reference of the order in the partner system. It must be unique.
+	CustomerRefID string         `json:"customer_ref_id"`
+	Address       Address        `json:"address"`
+	Products      []OrderProduct `json:"products"`
+}
+
+// CreateOrderParam is the parameter needed to create dropshipment orders.
+type CreateOrderParam struct {
+	Orders []NewOrder
+	// TestMode creates orders that are never shipped, for integration testing.
+	TestMode bool
+}
+
+// values encodes the parameter values.
+func (p CreateOrderParam) values() (url.Values, error) {
+	if len(p.Orders) == 0 {
+		return nil, errors.New("no order provided")
+	}
+	for _, order := range p.Orders {
+		if order.CustomerRefID == "" {
+			return nil, errors.New("order has no customer reference")
+		}
+		if len(order.Products) == 0 {
+			return nil, fmt.Errorf("order %s has no product", order.CustomerRefID)
+		}
+	}
+
+	orders, err := json.Marshal(p.Orders)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode orders: %w", err)
+	}
+
+	v := url.Values{}
+	v.Add("order", string(orders))
+	if p.TestMode {
+		v.Add("testmode", "1")
+	}
+	return v, nil
+}
+
+// UpdateOrderParam is the parameter needed to update a dropshipment order that has not shipped yet. Only the fields
+// provided are updated.
+type UpdateOrderParam struct {
+	OrderID  string
+	Address  *Address
+	Products []OrderProduct
+}
+
+// values encodes the parameter values.
+func (p UpdateOrderParam) values() (url.Values, error) {
+	if p.OrderID == "" {
+		return nil, errors.New("no order ID provided")
+	}
+
+	v := url.Values{}
+	v.Add("order_id", p.OrderID)
+	if p.Address != nil {
+		address, err := json.Marshal(p.Address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode address: %w", err)
+		}
+		v.Add("address", string(address))
+	}
+	if len(p.Products) > 0 {
+		products, err := json.Marshal(p.Products)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode products: %w", err)
+		}
+		v.Add("products", string(products))
+	}
+	return v, nil
+}
+
+// ordersResponse is the raw response of the dropshipment API requests.
+type ordersResponse struct {
+	Orders []Order `json:"orders"`
+}
+
+// Order is a dropshipment order as returned by the API. The carrier fields are set once the order shipped.
+type Order struct {
+	OrderID        string         `json:"order_id"`
+	CustomerRefID  string         `json:"customer_ref_id"`
+	Status         OrderStatus    `json:"status"`
+	Carrier        string         `json:"carrier"`
+	CarrierService string         `json:"carrier_service"`
+	TrackingNumber string         `json:"tracking_number"`
+	ParcelStatus   string         `json:"parcel_status"`
+	Products       []OrderProduct `json:"products"`
+}
+
+// HasTracking reports whether the carrier tracking of the order is available.
+func (o Order) HasTracking() bool {
+	return o.TrackingNumber != ""
+}
+
+// CreateDropshipmentOrder creates the orders provided and returns them with the ID Withings assigned to them.
+func (client *Client) CreateDropshipmentOrder(ctx context.Context, param CreateOrderParam) ([]Order, error) {
+	v, err := param.values()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate values: %w", err)
+	}
+
+	resp := ordersResponse{}
+	if err := client.SignedRequest(ctx, DropshipmentV2URL, "createorder", v, &resp); err != nil {
+		return nil, fmt.Errorf("failed to create orders: %w", err)
+	}
+	return resp.Orders, nil
+}
+
+// GetOrderStatus returns the orders with the IDs provided.
+func (client *Client) GetOrderStatus(ctx context.Context, orderIDs ...string) ([]Order, error) {
+	if len(orderIDs) == 0 {
+		return nil, errors.New("no order ID provided")
+	}
+
+	ids, err := json.Marshal(orderIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode order IDs: %w", err)
+	}
+	v := url.Values{}
+	v.Add("order_ids", string(ids))
+
+	resp := ordersResponse{}
+	if err := client.SignedRequest(ctx, DropshipmentV2URL, "getorderstatus", v, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get order status: %w", err)
+	}
+	return resp.Orders, nil
+}
+
+// UpdateOrder updates the address or products of an order that has not shipped yet and returns the updated order.
+func (client *Client) UpdateOrder(ctx context.Context, param UpdateOrderParam) (Order, error) {
+	v, err := param.values()
+	if err != nil {
+		return Order{}, fmt.Errorf("failed to generate values: %w", err)
+	}
+
+	resp := ordersResponse{}
+	if err := client.SignedRequest(ctx, DropshipmentV2URL, "update", v, &resp); err != nil {
+		return Order{}, fmt.Errorf("failed to update order %s: %w", param.OrderID, err)
+	}
+	for _, order := range resp.Orders {
+		if order.OrderID == param.OrderID {
+			return order, nil
+		}
+	}
+	return Order{}, fmt.Errorf("order %s missing from update response", param.OrderID)
+}
+
+// DeleteOrder cancels an order that has not shipped yet.
+func (client *Client) DeleteOrder(ctx context.Context, orderID string) error {
+	if orderID == "" {
+		return errors.New("no order ID provided")
+	}
+
+	v := url.Values{}
+	v.Add("order_id", orderID)
+
+	if err := client.SignedRequest(ctx, DropshipmentV2URL, "delete", v, nil); err != nil {
+		return fmt.Errorf("failed to delete order %s: %w", orderID, err)
+	}
+	return nil
+}
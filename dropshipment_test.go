@@ -0,0 +1,54 @@
+package gowithings_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/canadyworkshop/gowithings"
+)
+
+func TestOrder_Decode(t *testing.T) {
+	body := `{"order_id":"A1","customer_ref_id":"patient-7","status":"SHIPPED","carrier":"UPS",
+		"carrier_service":"Ground","tracking_number":"1Z999","parcel_status":"in_transit",
+		"products":[{"ean":"3700546702518","quantity":1}]}`
+
+	var order gowithings.Order
+	if err := json.Unmarshal([]byte(body), &order); err != nil {
+		t.Fatal(err)
+	}
+	if order.Status != gowithings.OrderStatusShipped || !order.Status.IsShipped() || order.Status.IsFinal() {
+		t.Errorf("status = %q, want shipped and not final", order.Status)
+	}
+	if !order.HasTracking() || order.Carrier != "UPS" {
+		t.Errorf("order = %+v, want UPS tracking", order)
+	}
+	if len(order.Products) != 1 || order.Products[0].Quantity != 1 {
+		t.Errorf("products = %+v", order.Products)
+	}
+}
+
+func TestOrderStatus(t *testing.T) {
+	tests := []struct {
+		status  gowithings.OrderStatus
+		known   bool
+		final   bool
+		shipped bool
+	}{
+		{gowithings.OrderStatusCreated, true, false, false},
+		{gowithings.OrderStatusToShip, true, false, false},
+		{gowithings.OrderStatusDelivered, true, true, true},
+		{gowithings.OrderStatusFailed, true, true, false},
+		{"LOST", false, false, false},
+	}
+	for _, tt := range tests {
+		if got := tt.status.IsKnown(); got != tt.known {
+			t.Errorf("%q.IsKnown() = %v, want %v", tt.status, got, tt.known)
+		}
+		if got := tt.status.IsFinal(); got != tt.final {
+			t.Errorf("%q.IsFinal() = %v, want %v", tt.status, got, tt.final)
+		}
+		if got := tt.status.IsShipped(); got != tt.shipped {
+			t.Errorf("%q.IsShipped() = %v, want %v", tt.status, got, tt.shipped)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package gowithings
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Exports of unexported helpers for the tests of the gowithings_test package.
+
+// BackfillWindows returns the start and end of the windows the backfill is split into.
+func BackfillWindows(p BackfillParam) [][2]time.Time {
+	return exportWindows(p.windows())
+}
+
+// exportWindows converts time windows into start and end pairs.
+func exportWindows(windows []timeWindow) [][2]time.Time {
+	pairs := make([][2]time.Time, len(windows))
+	for i, w := range windows {
+		pairs[i] = [2]time.Time{w.start, w.end}
+	}
+	return pairs
+}
+
+var MergeMeasureGroups = mergeMeasureGroups
+
+// RateLimiterWait returns the wait function of a new rate limiter allowing perMinute requests per minute.
+func RateLimiterWait(perMinute int) func(context.Context) error {
+	return newRateLimiter(perMinute).wait
+}
+
+// SplitTimeRange returns the start and end of the windows the range is split into.
+func SplitTimeRange(start, end time.Time, size time.Duration) [][2]time.Time {
+	return exportWindows(splitTimeRange(start, end, size))
+}
+
+var DecodeTimeSeries = decodeTimeSeries
+
+// GoalsFromResponse decodes the goals from the body of a get goals response.
+func GoalsFromResponse(body string) (Goals, error) {
+	resp := goalsResponse{}
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		return Goals{}, err
+	}
+	return resp.Goals.goals(), nil
+}
+
+var NewObjectives = newObjectives
+
+var (
+	NotifyValues             = notifyValues
+	SubscriptionValues       = subscriptionValues
+	SubscribeValues          = subscribeValues
+	UpdateSubscriptionValues = updateSubscriptionValues
+)
@@ -0,0 +1,68 @@
+package gowithings
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"time"
+)
+
+// goalsResponse is the raw response of a get goals API request.
+type goalsResponse struct {
+	Goals rawGoals `json:"goals"`
+}
+
+// rawGoals are the goals as returned by the API. Goals the user has not set are omitted.
+type rawGoals struct {
+	Steps  *int64     `json:"steps"`
+	Sleep  *int64     `json:"sleep"`
+	Weight *GoalValue `json:"weight"`
+}
+
+// GoalValue is a goal value expressed like a measure, as Value * 10^Unit.
+type GoalValue struct {
+	Value int64 `json:"value"`
+	Unit  int64 `json:"unit"`
+}
+
+// Float64 returns the value of the goal.
+func (v GoalValue) Float64() float64 {
+	return float64(v.Value) * math.Pow10(int(v.Unit))
+}
+
+// Goals are the goals a user set in the Withings app. Fields of goals the user has not set are nil.
+type Goals struct {
+	// Steps is the daily step goal.
+	Steps *int64
+	// Sleep is the nightly sleep duration goal.
+	Sleep *time.Duration
+	// Weight is the weight goal in kilograms.
+	Weight *GoalValue
+}
+
+// goals converts the raw goals, turning the sleep goal in seconds into a duration.
+func (g rawGoals) goals() Goals {
+	goals := Goals{
+		Steps:  g.Steps,
+		Weight: g.Weight,
+	}
+	if g.Sleep != nil {
+		sleep := time.Duration(*g.Sleep) * time.Second
+		goals.Sleep = &sleep
+	}
+	return goals
+}
+
+// GetGoals returns the step, sleep and weight goals of the user.
+func (c *UserClient) GetGoals(ctx context.Context) (Goals, error) {
+	v := url.Values{}
+	v.Add("action", "getgoals")
+
+	resp := goalsResponse{}
+	if _, err := c.post(ctx, UserV2URL, v.Encode(), &resp); err != nil {
+		return Goals{}, fmt.Errorf("failed to get goals: %w", err)
+	}
+
+	return resp.Goals.goals(), nil
+}
@@ -0,0 +1,53 @@
+package gowithings_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/canadyworkshop/gowithings"
+)
+
+func TestGoals_Decode(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		steps  int64
+		sleep  time.Duration
+		weight float64
+	}{
+		{
+			name:   "all goals",
+			body:   `{"goals":{"steps":10000,"sleep":28800,"weight":{"value":70500,"unit":-3}}}`,
+			steps:  10000,
+			sleep:  8 * time.Hour,
+			weight: 70.5,
+		},
+		{
+			name:  "steps only",
+			body:  `{"goals":{"steps":8000}}`,
+			steps: 8000,
+		},
+		{
+			name: "no goals",
+			body: `{"goals":{}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			goals, err := gowithings.GoalsFromResponse(tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (goals.Steps != nil) != (tt.steps != 0) || (goals.Steps != nil && *goals.Steps != tt.steps) {
+				t.Errorf("Steps = %v, want %d", goals.Steps, tt.steps)
+			}
+			if (goals.Sleep != nil) != (tt.sleep != 0) || (goals.Sleep != nil && *goals.Sleep != tt.sleep) {
+				t.Errorf("Sleep = %v, want %v", goals.Sleep, tt.sleep)
+			}
+			if (goals.Weight != nil) != (tt.weight != 0) || (goals.Weight != nil && goals.Weight.Float64() != tt.weight) {
+				t.Errorf("Weight = %v, want %v", goals.Weight, tt.weight)
+			}
+		})
+	}
+}
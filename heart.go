@@ -0,0 +1,260 @@
+package gowithings
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// AFibResult is the classification of an ECG recording for atrial fibrillation.
+type AFibResult int64
+
+const (
+	AFibNegative     AFibResult = 0
+	AFibPositive     AFibResult = 1
+	AFibInconclusive AFibResult = 2
+)
+
+// String returns the name of the result or its numeric value if it is unknown.
+func (r AFibResult) String() string {
+	switch r {
+	case AFibNegative:
+		return "Negative"
+	case AFibPositive:
+		return "Positive"
+	case AFibInconclusive:
+		return "Inconclusive"
+	}
+	return fmt.Sprintf("AFibResult(%d)", int64(r))
+}
+
+// ECGListParam is the parameter needed to specify what ECG recordings to list. Without dates every recording is
+// listed.
+type ECGListParam struct {
+	StartDate time.Time
+	EndDate   time.Time
+	Offset    int
+}
+
+// URLEncode encodes the parameter into the form values of a list ECG request.
+func (p ECGListParam) URLEncode() (string, error) {
+	v := url.Values{}
+	v.Add("action", "list")
+
+	if !p.StartDate.IsZero() {
+		v.Add("startdate", strconv.FormatInt(p.StartDate.Unix(), 10))
+	}
+
+	if !p.EndDate.IsZero() {
+		v.Add("enddate", strconv.FormatInt(p.EndDate.Unix(), 10))
+	}
+
+	if p.Offset > 0 {
+		v.Add("offset", strconv.Itoa(p.Offset))
+	}
+
+	return v.Encode(), nil
+}
+
+// ECGListResponse is the raw response of a list ECG API request.
+type ECGListResponse struct {
+	Series []ECG `json:"series"`
+	More   bool  `json:"more"`
+	Offset int   `json:"offset"`
+}
+
+// nextOffset implements pagedResponse.
+func (r ECGListResponse) nextOffset() (int, bool) {
+	return r.Offset, r.More && r.Offset != 0
+}
+
+// ECG is an ECG recording as listed by the API.
+type ECG struct {
+	DeviceID  string       `json:"deviceid"`
+	Model     int64        `json:"model"`
+	Recording ECGRecording `json:"ecg"`
+	// BloodPressure is the reading taken along with the ECG by blood pressure monitors, if any.
+	BloodPressure *ECGBloodPressure `json:"bloodpressure"`
+	HeartRate     int64             `json:"heart_rate"`
+	Timestamp     int64             `json:"timestamp"`
+	Timezone      string            `json:"timezone"`
+
+	// Intervals are the intervals derived from the recording, set by JoinECGIntervals.
+	Intervals *ECGIntervals `json:"-"`
+}
+
+// ECGRecording identifies the raw signal of an ECG and its classification.
+type ECGRecording struct {
+	SignalID int64      `json:"signalid"`
+	AFib     AFibResult `json:"afib"`
+}
+
+// ECGBloodPressure is a blood pressure reading in mmHg taken along with an ECG.
+type ECGBloodPressure struct {
+	Diastole int64 `json:"diastole"`
+	Systole  int64 `json:"systole"`
+}
+
+// ECGIntervals are the intervals derived from an ECG recording, as reported by the QRS, PR, QT and CorrectedQT
+// measure types.
+type ECGIntervals struct {
+	// GroupID is the measure group the intervals were read from.
+	GroupID     int64
+	QRS         time.Duration
+	PR          time.Duration
+	QT          time.Duration
+	CorrectedQT time.Duration
+}
+
+// Measure type codes of the intervals derived from an ECG.
+const (
+	measureTypeQRS         = 135
+	measureTypePR          = 136
+	measureTypeQT          = 137
+	measureTypeCorrectedQT = 138
+)
+
+// ECGIntervalMeasureTypes are the measure types of the intervals derived from an ECG, for use in GetMeasureParam.
+var ECGIntervalMeasureTypes = []string{
+	MeasureTypes["QRS"], MeasureTypes["PR"], MeasureTypes["QT"], MeasureTypes["CorrectedQT"],
+}
+
+// Location returns the timezone the recording was made in, falling back to UTC.
+func (e ECG) Location() *time.Location {
+	return resolveLocation(e.Timezone)
+}
+
+// RecordedAt returns the time of the recording in the timezone it was made in.
+func (e ECG) RecordedAt() time.Time {
+	return time.Unix(e.Timestamp, 0).In(e.Location())
+}
+
+// ecgIntervals reads the ECG intervals of a measure group. The second value is false if the group has none.
+func ecgIntervals(mg MeasureGroup) (ECGIntervals, bool) {
+	intervals := ECGIntervals{GroupID: mg.GroupID}
+	found := false
+	for _, m := range mg.Measures {
+		// Intervals are reported in milliseconds.
+		d := time.Duration(m.ValueFloat64() * float64(time.Millisecond))
+		switch m.Type {
+		case measureTypeQRS:
+			intervals.QRS = d
+		case measureTypePR:
+			intervals.PR = d
+		case measureTypeQT:
+			intervals.QT = d
+		case measureTypeCorrectedQT:
+			intervals.CorrectedQT = d
+		default:
+			continue
+		}
+		found = true
+	}
+	return intervals, found
+}
+
+// JoinECGIntervals sets the Intervals of each ECG from the measure group carrying the ECG intervals that was
+// measured closest to the recording, within the tolerance provided. The groups are typically fetched with
+// ECGIntervalMeasureTypes over the same date range as the ECGs.
+func JoinECGIntervals(ecgs []ECG, groups []MeasureGroup, tolerance time.Duration) {
+	for i := range ecgs {
+		var best *ECGIntervals
+		bestDelta := tolerance
+		for _, mg := range groups {
+			delta := time.Duration(mg.Date-ecgs[i].Timestamp) * time.Second
+			if delta < 0 {
+				delta = -delta
+			}
+			if delta > bestDelta {
+				continue
+			}
+			if mg.DeviceID != "" && ecgs[i].DeviceID != "" && mg.DeviceID != ecgs[i].DeviceID {
+				continue
+			}
+			intervals, ok := ecgIntervals(mg)
+			if !ok {
+				continue
+			}
+			best = &intervals
+			bestDelta = delta
+		}
+		ecgs[i].Intervals = best
+	}
+}
+
+// ListECGs returns the ECG recordings as specified by the request param, iterating over the offset until every
+// recording is retrieved.
+func (c *UserClient) ListECGs(ctx context.Context, param ECGListParam) ([]ECG, error) {
+	return getAllPages(ctx, c, HeartV2URL, "list ECGs", param.Offset, func(offset int) (string, error) {
+		param.Offset = offset
+		return param.URLEncode()
+	}, func(resp ECGListResponse) []ECG {
+		return resp.Series
+	})
+}
+
+// ECGSignal is the raw waveform of an ECG recording. ScanWatch and BPM Core record a single lead between the wrist
+// or arm the device is worn on and the opposite hand, so WearPosition tells which side the lead was taken from.
+type ECGSignal struct {
+	SignalID int64 `json:"-"`
+	// Samples is the signal in microvolts.
+	Samples []int32 `json:"signal"`
+	// SamplingFrequency is the number of samples per second.
+	SamplingFrequency int          `json:"sampling_frequency"`
+	WearPosition      BodyPosition `json:"wearposition"`
+}
+
+// Microvolts returns the samples of the signal in microvolts as floats.
+func (s ECGSignal) Microvolts() []float64 {
+	values := make([]float64, len(s.Samples))
+	for i, v := range s.Samples {
+		values[i] = float64(v)
+	}
+	return values
+}
+
+// Millivolts returns the samples of the signal in millivolts.
+func (s ECGSignal) Millivolts() []float64 {
+	values := make([]float64, len(s.Samples))
+	for i, v := range s.Samples {
+		values[i] = float64(v) / 1000
+	}
+	return values
+}
+
+// SampleOffset returns the time of sample i relative to the start of the recording.
+func (s ECGSignal) SampleOffset(i int) time.Duration {
+	if s.SamplingFrequency <= 0 {
+		return 0
+	}
+	return time.Duration(i) * time.Second / time.Duration(s.SamplingFrequency)
+}
+
+// Duration returns the length of the recording.
+func (s ECGSignal) Duration() time.Duration {
+	return s.SampleOffset(len(s.Samples))
+}
+
+// SampleTime returns the time of sample i for a recording that started at the time provided, such as
+// ECG.RecordedAt.
+func (s ECGSignal) SampleTime(start time.Time, i int) time.Time {
+	return start.Add(s.SampleOffset(i))
+}
+
+// GetECGSignal returns the raw waveform of the ECG recording identified by the signal ID, as found in
+// ECG.Recording.SignalID.
+func (c *UserClient) GetECGSignal(ctx context.Context, signalID int64) (ECGSignal, error) {
+	v := url.Values{}
+	v.Add("action", "get")
+	v.Add("signalid", strconv.FormatInt(signalID, 10))
+
+	signal := ECGSignal{}
+	if _, err := c.post(ctx, HeartV2URL, v.Encode(), &signal); err != nil {
+		return signal, fmt.Errorf("failed to get ECG signal %d: %w", signalID, err)
+	}
+	signal.SignalID = signalID
+
+	return signal, nil
+}
@@ -0,0 +1,82 @@
+package gowithings_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/canadyworkshop/gowithings"
+)
+
+func TestJoinECGIntervals(t *testing.T) {
+	ecgs := []gowithings.ECG{
+		{DeviceID: "watch", Timestamp: 1000},
+		{DeviceID: "watch", Timestamp: 5000},
+	}
+	groups := []gowithings.MeasureGroup{
+		{GroupID: 1, DeviceID: "watch", Date: 1030, Measures: []gowithings.Measure{
+			{Type: 135, Value: 96, Unit: 0},
+			{Type: 138, Value: 412, Unit: 0},
+		}},
+		{GroupID: 2, DeviceID: "watch", Date: 1002, Measures: []gowithings.Measure{
+			{Type: 135, Value: 94, Unit: 0},
+		}},
+		{GroupID: 3, DeviceID: "scale", Date: 5000, Measures: []gowithings.Measure{
+			{Type: 135, Value: 90, Unit: 0},
+		}},
+		{GroupID: 4, DeviceID: "watch", Date: 5000, Measures: []gowithings.Measure{
+			{Type: 1, Value: 72000, Unit: -3},
+		}},
+	}
+
+	gowithings.JoinECGIntervals(ecgs, groups, time.Minute)
+
+	if ecgs[0].Intervals == nil {
+		t.Fatal("first ECG has no intervals")
+	}
+	if ecgs[0].Intervals.GroupID != 2 {
+		t.Errorf("GroupID = %d, want closest group 2", ecgs[0].Intervals.GroupID)
+	}
+	if ecgs[0].Intervals.QRS != 94*time.Millisecond {
+		t.Errorf("QRS = %v, want 94ms", ecgs[0].Intervals.QRS)
+	}
+	if ecgs[1].Intervals != nil {
+		t.Errorf("second ECG joined group %d of another device or without intervals", ecgs[1].Intervals.GroupID)
+	}
+}
+
+func TestECGSignal_Decode(t *testing.T) {
+	body := `{"signal":[100,-250,400],"sampling_frequency":500,"wearposition":3}`
+
+	var signal gowithings.ECGSignal
+	if err := json.Unmarshal([]byte(body), &signal); err != nil {
+		t.Fatal(err)
+	}
+	if signal.WearPosition != gowithings.BodyPositionLeftArm {
+		t.Errorf("WearPosition = %v, want LeftArm", signal.WearPosition)
+	}
+	if got := signal.Duration(); got != 6*time.Millisecond {
+		t.Errorf("Duration() = %v, want 6ms", got)
+	}
+	if got := signal.Millivolts()[1]; got != -0.25 {
+		t.Errorf("Millivolts()[1] = %v, want -0.25", got)
+	}
+}
+
+func TestUserClient_ListECGs(t *testing.T) {
+	api := pagedAPI(map[string]string{
+		"0": `{"series":[{"heart_rate":60}],"more":true,"offset":1}`,
+		"1": `{"series":[{"heart_rate":72}],"more":false,"offset":0}`,
+	})
+	uc := newTestUserClient(t, api)
+
+	ecgs, err := uc.ListECGs(context.Background(), gowithings.ECGListParam{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ecgs) != 2 || ecgs[0].HeartRate != 60 || ecgs[1].HeartRate != 72 {
+		t.Errorf("ECGs = %+v, want both pages in order", ecgs)
+	}
+	checkOffsets(t, api, "", "1")
+}
@@ -0,0 +1,255 @@
+package gowithings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// IntradayMaxWindow is the longest time range the API returns intraday activity for in a single request.
+const IntradayMaxWindow = 24 * time.Hour
+
+// IntradayField is a field of the intraday activity series that can be requested from the API.
+type IntradayField string
+
+const (
+	IntradayFieldSteps     IntradayField = "steps"
+	IntradayFieldElevation IntradayField = "elevation"
+	IntradayFieldCalories  IntradayField = "calories"
+	IntradayFieldDistance  IntradayField = "distance"
+	IntradayFieldStroke    IntradayField = "stroke"
+	IntradayFieldPoolLap   IntradayField = "pool_lap"
+	IntradayFieldDuration  IntradayField = "duration"
+	IntradayFieldHeartRate IntradayField = "heart_rate"
+	IntradayFieldSPO2      IntradayField = "spo2_auto"
+)
+
+// AllIntradayFields is every field of the intraday activity series.
+var AllIntradayFields = []IntradayField{
+	IntradayFieldSteps, IntradayFieldElevation, IntradayFieldCalories, IntradayFieldDistance, IntradayFieldStroke,
+	IntradayFieldPoolLap, IntradayFieldDuration, IntradayFieldHeartRate, IntradayFieldSPO2,
+}
+
+// intradayResponse is the raw response of a get intraday activity API request. The series is keyed by the unix
+// timestamp of each sample.
+type intradayResponse struct {
+	Series map[string]intradaySample `json:"series"`
+}
+
+// intradaySample is a raw intraday sample. Fields are pointers so missing values can be told apart from zero.
+type intradaySample struct {
+	DeviceID  string   `json:"deviceid"`
+	Model     string   `json:"model"`
+	ModelID   int64    `json:"model_id"`
+	Steps     *float64 `json:"steps"`
+	Elevation *float64 `json:"elevation"`
+	Calories  *float64 `json:"calories"`
+	Distance  *float64 `json:"distance"`
+	Stroke    *float64 `json:"stroke"`
+	PoolLap   *float64 `json:"pool_lap"`
+	Duration  *float64 `json:"duration"`
+	HeartRate *float64 `json:"heart_rate"`
+	SPO2      *float64 `json:"spo2_auto"`
+}
+
+// IntradaySample is a single sample of an intraday activity series. Missing values are NaN.
+type IntradaySample struct {
+	Time      time.Time
+	DeviceID  string
+	Steps     float64
+	Elevation float64
+	Calories  float64
+	Distance  float64
+	Stroke    float64
+	PoolLap   float64
+	Duration  float64
+	HeartRate float64
+	SPO2      float64
+}
+
+// IntradayActivity is an intraday activity series stored by column to keep long series compact. Every column has one
+// value per timestamp, with NaN for missing values, or is nil if the field was not requested.
+type IntradayActivity struct {
+	// Timestamps are the unix timestamps of the samples in ascending order.
+	Timestamps []int64
+	// Devices is the table of device IDs referenced by DeviceIndex.
+	Devices     []string
+	DeviceIndex []uint16
+
+	Steps     []float32
+	Elevation []float32
+	Calories  []float32
+	Distance  []float32
+	Stroke    []float32
+	PoolLap   []float32
+	Duration  []float32
+	HeartRate []float32
+	SPO2      []float32
+
+	devices map[string]uint16
+}
+
+// Len returns the number of samples in the series.
+func (a *IntradayActivity) Len() int {
+	return len(a.Timestamps)
+}
+
+// Time returns the time of the sample at index i.
+func (a *IntradayActivity) Time(i int) time.Time {
+	return time.Unix(a.Timestamps[i], 0)
+}
+
+// columnValue returns the value of the column at index i or NaN if the column was not requested.
+func columnValue(column []float32, i int) float64 {
+	if column == nil {
+		return math.NaN()
+	}
+	return float64(column[i])
+}
+
+// At returns the sample at index i.
+func (a *IntradayActivity) At(i int) IntradaySample {
+	return IntradaySample{
+		Time:      a.Time(i),
+		DeviceID:  a.Devices[a.DeviceIndex[i]],
+		Steps:     columnValue(a.Steps, i),
+		Elevation: columnValue(a.Elevation, i),
+		Calories:  columnValue(a.Calories, i),
+		Distance:  columnValue(a.Distance, i),
+		Stroke:    columnValue(a.Stroke, i),
+		PoolLap:   columnValue(a.PoolLap, i),
+		Duration:  columnValue(a.Duration, i),
+		HeartRate: columnValue(a.HeartRate, i),
+		SPO2:      columnValue(a.SPO2, i),
+	}
+}
+
+// Samples returns every sample of the series. Prefer At or the columns for long series.
+func (a *IntradayActivity) Samples() []IntradaySample {
+	samples := make([]IntradaySample, a.Len())
+	for i := range samples {
+		samples[i] = a.At(i)
+	}
+	return samples
+}
+
+// newIntradayActivity creates an empty series with a column for each of the fields provided.
+func newIntradayActivity(fields []IntradayField) *IntradayActivity {
+	a := &IntradayActivity{devices: make(map[string]uint16)}
+	for _, f := range fields {
+		switch f {
+		case IntradayFieldSteps:
+			a.Steps = []float32{}
+		case IntradayFieldElevation:
+			a.Elevation = []float32{}
+		case IntradayFieldCalories:
+			a.Calories = []float32{}
+		case IntradayFieldDistance:
+			a.Distance = []float32{}
+		case IntradayFieldStroke:
+			a.Stroke = []float32{}
+		case IntradayFieldPoolLap:
+			a.PoolLap = []float32{}
+		case IntradayFieldDuration:
+			a.Duration = []float32{}
+		case IntradayFieldHeartRate:
+			a.HeartRate = []float32{}
+		case IntradayFieldSPO2:
+			a.SPO2 = []float32{}
+		}
+	}
+	return a
+}
+
+// appendColumn appends the value to the column if the column was requested.
+func appendColumn(column []float32, value *float64) []float32 {
+	if column == nil {
+		return nil
+	}
+	if value == nil {
+		return append(column, float32(math.NaN()))
+	}
+	return append(column, float32(*value))
+}
+
+// append merges the samples of a response into the series. Samples at or before the last timestamp of the series
+// are dropped so windows can be appended in order without duplicates.
+func (a *IntradayActivity) append(series map[string]intradaySample) error {
+	timestamps := make([]int64, 0, len(series))
+	samples := make(map[int64]intradaySample, len(series))
+	for key, sample := range series {
+		ts, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid sample timestamp %q: %w", key, err)
+		}
+		timestamps = append(timestamps, ts)
+		samples[ts] = sample
+	}
+	sort.Slice(timestamps, func(i, j int) bool { return timestamps[i] < timestamps[j] })
+
+	for _, ts := range timestamps {
+		if n := len(a.Timestamps); n > 0 && ts <= a.Timestamps[n-1] {
+			continue
+		}
+		sample := samples[ts]
+
+		index, ok := a.devices[sample.DeviceID]
+		if !ok {
+			if len(a.Devices) > math.MaxUint16 {
+				return errors.New("too many devices in intraday series")
+			}
+			index = uint16(len(a.Devices))
+			a.devices[sample.DeviceID] = index
+			a.Devices = append(a.Devices, sample.DeviceID)
+		}
+
+		a.Timestamps = append(a.Timestamps, ts)
+		a.DeviceIndex = append(a.DeviceIndex, index)
+		a.Steps = appendColumn(a.Steps, sample.Steps)
+		a.Elevation = appendColumn(a.Elevation, sample.Elevation)
+		a.Calories = appendColumn(a.Calories, sample.Calories)
+		a.Distance = appendColumn(a.Distance, sample.Distance)
+		a.Stroke = appendColumn(a.Stroke, sample.Stroke)
+		a.PoolLap = appendColumn(a.PoolLap, sample.PoolLap)
+		a.Duration = appendColumn(a.Duration, sample.Duration)
+		a.HeartRate = appendColumn(a.HeartRate, sample.HeartRate)
+		a.SPO2 = appendColumn(a.SPO2, sample.SPO2)
+	}
+	return nil
+}
+
+// GetIntradayActivity returns the intraday activity series between start and end for the fields provided, or all
+// fields if none are provided. Ranges longer than IntradayMaxWindow are split into several requests and merged into
+// a single series sorted by time.
+func (c *UserClient) GetIntradayActivity(ctx context.Context, start, end time.Time, fields []IntradayField) (*IntradayActivity, error) {
+	if !start.Before(end) {
+		return nil, errors.New("start must be before end")
+	}
+	if len(fields) == 0 {
+		fields = AllIntradayFields
+	}
+
+	activity := newIntradayActivity(fields)
+	for _, w := range splitTimeRange(start, end, IntradayMaxWindow) {
+		v := url.Values{}
+		v.Add("action", "getintradayactivity")
+		v.Add("startdate", strconv.FormatInt(w.start.Unix(), 10))
+		v.Add("enddate", strconv.FormatInt(w.end.Unix(), 10))
+		v.Add("data_fields", joinFields(fields))
+
+		resp := intradayResponse{}
+		if _, err := c.post(ctx, MeasureV2URL, v.Encode(), &resp); err != nil {
+			return nil, fmt.Errorf("failed to get intraday activity from %s: %w", w.start.Format(time.RFC3339), err)
+		}
+		if err := activity.append(resp.Series); err != nil {
+			return nil, err
+		}
+	}
+
+	return activity, nil
+}
@@ -0,0 +1,115 @@
+package gowithings_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/canadyworkshop/gowithings"
+)
+
+func TestSplitTimeRange(t *testing.T) {
+	start := time.Unix(0, 0)
+	tests := []struct {
+		name  string
+		end   time.Time
+		size  time.Duration
+		count int
+	}{
+		{"shorter than window", start.Add(time.Hour), 24 * time.Hour, 1},
+		{"exact multiple", start.Add(48 * time.Hour), 24 * time.Hour, 2},
+		{"partial last window", start.Add(50 * time.Hour), 24 * time.Hour, 3},
+		{"empty range", start, 24 * time.Hour, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			windows := gowithings.SplitTimeRange(start, tt.end, tt.size)
+			if len(windows) != tt.count {
+				t.Fatalf("got %d windows, want %d", len(windows), tt.count)
+			}
+			if tt.count == 0 {
+				return
+			}
+			if windows[0][0] != start || windows[len(windows)-1][1] != tt.end {
+				t.Errorf("windows cover %v to %v, want %v to %v", windows[0][0], windows[len(windows)-1][1], start, tt.end)
+			}
+			for i := 1; i < len(windows); i++ {
+				if !windows[i][0].After(windows[i-1][1]) {
+					t.Errorf("window %d overlaps the previous one", i)
+				}
+			}
+		})
+	}
+}
+
+func TestUserClient_GetIntradayActivity(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	end := start.Add(36 * time.Hour)
+	second := start.Add(gowithings.IntradayMaxWindow)
+
+	// The second window repeats the last sample of the first one, and samples arrive out of order.
+	api := &fakeAPI{respond: func(form url.Values) string {
+		if form.Get("startdate") == strconv.FormatInt(start.Unix(), 10) {
+			return fmt.Sprintf(`{"series":{"%d":{"deviceid":"watch","steps":12},"%d":{"deviceid":"watch","steps":3,"heart_rate":62}}}`,
+				start.Unix()+160, start.Unix()+100)
+		}
+		return fmt.Sprintf(`{"series":{"%d":{"deviceid":"scale","heart_rate":70},"%d":{"deviceid":"watch","steps":99}}}`,
+			second.Unix()+100, start.Unix()+160)
+	}}
+	uc := newTestUserClient(t, api)
+
+	fields := []gowithings.IntradayField{gowithings.IntradayFieldSteps, gowithings.IntradayFieldHeartRate}
+	a, err := uc.GetIntradayActivity(context.Background(), start, end, fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requests := api.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("got %d requests, want one per window", len(requests))
+	}
+	if got := requests[1].Get("enddate"); got != strconv.FormatInt(end.Unix(), 10) {
+		t.Errorf("last window ends at %s, want %d", got, end.Unix())
+	}
+	if got := requests[0].Get("data_fields"); got != "steps,heart_rate" {
+		t.Errorf("data_fields = %s, want steps,heart_rate", got)
+	}
+
+	if a.Len() != 3 {
+		t.Fatalf("got %d samples, want 3", a.Len())
+	}
+	wantTimes := []int64{start.Unix() + 100, start.Unix() + 160, second.Unix() + 100}
+	for i, ts := range wantTimes {
+		if a.Timestamps[i] != ts {
+			t.Errorf("timestamp %d = %d, want %d", i, a.Timestamps[i], ts)
+		}
+	}
+
+	if s := a.At(1); s.Steps != 12 || !math.IsNaN(s.HeartRate) || s.DeviceID != "watch" {
+		t.Errorf("sample 1 = %+v, want 12 steps and no heart rate from watch", s)
+	}
+	if s := a.At(2); !math.IsNaN(s.Steps) || s.HeartRate != 70 || s.DeviceID != "scale" {
+		t.Errorf("sample 2 = %+v, want a 70 heart rate from scale", s)
+	}
+	if s := a.At(0); !math.IsNaN(s.Calories) || a.Calories != nil {
+		t.Errorf("calories were not requested but got %v", s.Calories)
+	}
+	if len(a.Devices) != 2 {
+		t.Errorf("devices = %v, want watch and scale", a.Devices)
+	}
+}
+
+func TestUserClient_GetIntradayActivity_InvalidTimestamp(t *testing.T) {
+	uc := newTestUserClient(t, &fakeAPI{respond: func(url.Values) string {
+		return `{"series":{"soon":{"steps":1}}}`
+	}})
+
+	start := time.Unix(1700000000, 0)
+	if _, err := uc.GetIntradayActivity(context.Background(), start, start.Add(time.Hour), nil); err == nil {
+		t.Error("expected an error for an invalid timestamp")
+	}
+}
@@ -91,24 +91,63 @@ var MeasureTypesByKey = map[int]string{
 	229: "ElectrochemicalSkinConductance",
 }
 
+// MeasureCategory tells real measurements apart from the targets a user has set.
+type MeasureCategory int64
+
 const (
-	MeasureCategoryRealMeasures   = "1"
-	MeasureCateogryUserObjectives = "2"
+	MeasureCategoryRealMeasures   MeasureCategory = 1
+	MeasureCategoryUserObjectives MeasureCategory = 2
+
+	// Deprecated: Use MeasureCategoryUserObjectives.
+	MeasureCateogryUserObjectives = MeasureCategoryUserObjectives
 )
 
-// GetMeasureParam is the parameter needed to specify what measures to retreive.
+// String returns the name of the category or its numeric value if it is unknown.
+func (c MeasureCategory) String() string {
+	switch c {
+	case MeasureCategoryRealMeasures:
+		return "RealMeasures"
+	case MeasureCategoryUserObjectives:
+		return "UserObjectives"
+	}
+	return fmt.Sprintf("MeasureCategory(%d)", int64(c))
+}
+
+// orDefault returns the category or MeasureCategoryRealMeasures if it is not set.
+func (c MeasureCategory) orDefault() MeasureCategory {
+	if c == 0 {
+		return MeasureCategoryRealMeasures
+	}
+	return c
+}
+
+// GetMeasureParam is the parameter needed to specify what measures to retreive. A zero Category requests real
+// measures and a request only ever returns groups of the category requested.
 type GetMeasureParam struct {
 	MeasureTypes []string
-	Category     string
+	Category     MeasureCategory
 	StartDate    time.Time
 	EndDate      time.Time
 	LastUpdate   time.Time
 	Offset       int
+
+	// ExcludeAmbiguous drops groups captured by a device that may belong to another user.
+	ExcludeAmbiguous bool
+	// ExcludeManual drops groups entered by hand instead of captured by a device.
+	ExcludeManual bool
+
+	// Strict enables strict decoding. Unknown JSON fields, measure types and attribs are reported in
+	// MeasureResponse.Warnings instead of being silently dropped.
+	Strict bool
+	// OnDecodeWarning, when set, is called for each warning found in strict mode. It is useful with GetAllMeasures
+	// which does not return the responses.
+	OnDecodeWarning func(DecodeWarning)
 }
 
 type GetMeasureResponseWrapper struct {
 	Status int             `json:"status"`
 	Body   MeasureResponse `json:"body"`
+	Error  string          `json:"error"`
 }
 
 // MeasureResponse is the raw response of a get measure API request.
@@ -118,37 +157,60 @@ type MeasureResponse struct {
 	More          int            `json:"more"`
 	Offset        int            `json:"offset"`
 	MeasureGroups []MeasureGroup `json:"measuregrps"`
+
+	// Warnings holds the API drift found when decoding in strict mode.
+	Warnings []DecodeWarning `json:"-"`
 }
 
 // MeasureGroup is a group of measurements as returned by the API. Each group of measurements were recoreded at the
 // exact same time by the same device.
 type MeasureGroup struct {
-	GroupID      int64     `json:"grpid"`
-	Attrib       int64     `json:"attrib"`
-	Date         int64     `json:"date"`
-	Created      int64     `json:"created"`
-	Modified     int64     `json:"modified"`
-	Category     int64     `json:"category"`
-	DeviceID     string    `json:"deviceid"`
-	HashDeviceID string    `json:"hash_device_id"`
-	Timezone     string    `json:"timezone"`
-	Measures     []Measure `json:"measures"`
+	GroupID      int64           `json:"grpid"`
+	Attrib       Attrib          `json:"attrib"`
+	Date         int64           `json:"date"`
+	Created      int64           `json:"created"`
+	Modified     int64           `json:"modified"`
+	Category     MeasureCategory `json:"category"`
+	DeviceID     string          `json:"deviceid"`
+	HashDeviceID string          `json:"hash_deviceid"`
+	Timezone     string          `json:"timezone"`
+	Measures     []Measure       `json:"measures"`
+
+	// RawJSON is the original payload of the group as returned by the API.
+	RawJSON json.RawMessage `json:"-"`
+
+	// responseTimezone is the timezone of the response the group was decoded from, used when the group timezone is
+	// missing or cannot be loaded.
+	responseTimezone string
+}
+
+// UnmarshalJSON decodes the group and keeps a copy of the original payload in RawJSON.
+func (mg *MeasureGroup) UnmarshalJSON(data []byte) error {
+	type plain MeasureGroup
+	if err := json.Unmarshal(data, (*plain)(mg)); err != nil {
+		return err
+	}
+	mg.RawJSON = append(json.RawMessage(nil), data...)
+	return nil
 }
 
 // Measure is a specific measurement as returned by the API.
 type Measure struct {
-	Value    int64   `json:"value"`
-	Type     int64   `json:"type"`
-	Unit     int64   `json:"unit"`
-	Algo     int64   `json:"algo"`
-	FM       float64 `json:"fm"`
-	Position int64   `json:"position"`
+	Value int64   `json:"value"`
+	Type  int64   `json:"type"`
+	Unit  int64   `json:"unit"`
+	Algo  int64   `json:"algo"`
+	FM    float64 `json:"fm"`
+	// Position is the body segment of segmental measures. It is nil for whole body measures.
+	Position *BodyPosition `json:"position"`
 }
 
 func (m Measure) ValueFloat64() float64 {
 	return float64(m.Value) * math.Pow10(int(m.Unit))
 }
 
+// MeasuredAt returns the time of the measurement in the local timezone of the process. Use LocalMeasuredAt for the
+// time in the timezone the group was measured in.
 func (mg MeasureGroup) MeasuredAt() time.Time {
 	return time.Unix(mg.Date, 0)
 }
@@ -167,10 +229,11 @@ func (m GetMeasureParam) URLEncode() (string, error) {
 		v.Add("meastypes", strings.Join(m.MeasureTypes, ","))
 	}
 
-	if m.Category == "0" || m.Category == "1" {
-		v.Add("category", "1")
-	} else {
-		v.Add("category", (m.Category))
+	switch category := m.Category.orDefault(); category {
+	case MeasureCategoryRealMeasures, MeasureCategoryUserObjectives:
+		v.Add("category", strconv.FormatInt(int64(category), 10))
+	default:
+		return "", fmt.Errorf("unknown measure category %d", category)
 	}
 
 	if !m.LastUpdate.IsZero() {
@@ -193,10 +256,60 @@ func (m GetMeasureParam) URLEncode() (string, error) {
 
 }
 
+// keep reports whether the group passes the filters of the parameter.
+func (m GetMeasureParam) keep(mg MeasureGroup) bool {
+	if mg.Category != m.Category.orDefault() {
+		return false
+	}
+	if m.ExcludeAmbiguous && mg.Attrib.IsAmbiguous() {
+		return false
+	}
+	if m.ExcludeManual && mg.Attrib.IsManual() {
+		return false
+	}
+	return true
+}
+
+// filter removes the groups that do not pass the filters of the parameter.
+func (m GetMeasureParam) filter(groups []MeasureGroup) []MeasureGroup {
+	kept := groups[:0]
+	for _, mg := range groups {
+		if m.keep(mg) {
+			kept = append(kept, mg)
+		}
+	}
+	return kept
+}
+
+// ParseMeasureResponse decodes a raw get measure API response. In strict mode the API drift found while decoding is
+// reported in MeasureResponse.Warnings.
+func ParseMeasureResponse(body []byte, strict bool) (MeasureResponse, error) {
+	response := GetMeasureResponseWrapper{}
+
+	if err := json.Unmarshal(body, &response); err != nil {
+		return response.Body, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if response.Status != 0 {
+		return response.Body, &APIError{Status: response.Status, Message: response.Error}
+	}
+
+	if strict {
+		response.Body.Warnings = measureWarnings(body, response.Body.MeasureGroups)
+	}
+	for i := range response.Body.MeasureGroups {
+		response.Body.MeasureGroups[i].responseTimezone = response.Body.Timezone
+	}
+
+	return response.Body, nil
+}
+
 // GetMeasure will return the measures as specified by the request param up to the API limit per response. If the
-// return set is larger the offset will be provided to perform a second request for additional measrues.
+// return set is larger the offset will be provided to perform a second request for additional measrues. Groups of
+// another category than requested or rejected by the ExcludeAmbiguous and ExcludeManual filters are removed from
+// the response.
 func (c *UserClient) GetMeasure(ctx context.Context, param GetMeasureParam) (MeasureResponse, error) {
-	apiURL := "https://wbsapi.withings.net/measure"
+	apiURL := MeasureURL
 
 	response := GetMeasureResponseWrapper{}
 
@@ -220,13 +333,16 @@ func (c *UserClient) GetMeasure(ctx context.Context, param GetMeasureParam) (Mea
 		return response.Body, err
 	}
 
-	if err := json.Unmarshal(body, &response); err != nil {
-		return response.Body, fmt.Errorf("failed to unmarshal response: %w", err)
+	response.Body, err = ParseMeasureResponse(body, param.Strict)
+	if err != nil {
+		return response.Body, err
 	}
-
-	if response.Status != 0 {
-		return response.Body, fmt.Errorf("failed with status %d", response.Status)
+	if param.OnDecodeWarning != nil {
+		for _, w := range response.Body.Warnings {
+			param.OnDecodeWarning(w)
+		}
 	}
+	response.Body.MeasureGroups = param.filter(response.Body.MeasureGroups)
 
 	return response.Body, nil
 }
@@ -234,12 +350,22 @@ func (c *UserClient) GetMeasure(ctx context.Context, param GetMeasureParam) (Mea
 // GetAllMeasures will return measures as specified by the request and iterate over the offset until all measures are
 // retrieved.
 func (c *UserClient) GetAllMeasures(ctx context.Context, param GetMeasureParam) ([]MeasureGroup, error) {
+	measures, _, err := c.getAllMeasures(ctx, param)
+	return measures, err
+}
+
+// getAllMeasures returns the measures as GetAllMeasures does along with the update time of the first response, which
+// is the server time the request was served at.
+func (c *UserClient) getAllMeasures(ctx context.Context, param GetMeasureParam) ([]MeasureGroup, time.Time, error) {
 	measures := make([]MeasureGroup, 0)
-	done := false
-	for !done {
+	var updated time.Time
+	for {
 		resp, err := c.GetMeasure(ctx, param)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get all measures at offset %d: %w", param.Offset, err)
+			return nil, time.Time{}, fmt.Errorf("failed to get all measures at offset %d: %w", param.Offset, err)
+		}
+		if updated.IsZero() && resp.UpdateTime > 0 {
+			updated = time.Unix(resp.UpdateTime, 0)
 		}
 		measures = append(measures, resp.MeasureGroups...)
 
@@ -248,5 +374,5 @@ func (c *UserClient) GetAllMeasures(ctx context.Context, param GetMeasureParam)
 		}
 		param.Offset = resp.Offset
 	}
-	return measures, nil
+	return measures, updated, nil
 }
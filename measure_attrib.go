@@ -0,0 +1,75 @@
+package gowithings
+
+import "fmt"
+
+// Attrib describes how a measure group was captured and how much it can be trusted to belong to the user.
+type Attrib int64
+
+const (
+	// AttribDevice is a group captured by a device and known to belong to the user.
+	AttribDevice Attrib = 0
+	// AttribDeviceAmbiguous is a group captured by a device that may belong to another user of the same device,
+	// for example a guest stepping on a shared scale.
+	AttribDeviceAmbiguous Attrib = 1
+	// AttribManual is a group entered manually by the user.
+	AttribManual Attrib = 2
+	// AttribManualCreation is a group entered manually during account creation and may not be accurate.
+	AttribManualCreation Attrib = 4
+	// AttribAutoBloodPressure is a group computed by a blood pressure monitor from several readings.
+	AttribAutoBloodPressure Attrib = 5
+	// AttribConfirmed is a group the user confirmed after it was detected.
+	AttribConfirmed Attrib = 7
+	// AttribDeviceConfirmed is the same as AttribDevice.
+	AttribDeviceConfirmed Attrib = 8
+	// AttribGuided is a group measured in specific guided conditions (Nerve Health Score).
+	AttribGuided Attrib = 15
+	// AttribGuidedElectrodermal is a group measured in specific guided conditions (Nerve Health Score and
+	// electrodermal activity score).
+	AttribGuidedElectrodermal Attrib = 17
+)
+
+var attribNames = map[Attrib]string{
+	AttribDevice:              "Device",
+	AttribDeviceAmbiguous:     "DeviceAmbiguous",
+	AttribManual:              "Manual",
+	AttribManualCreation:      "ManualCreation",
+	AttribAutoBloodPressure:   "AutoBloodPressure",
+	AttribConfirmed:           "Confirmed",
+	AttribDeviceConfirmed:     "DeviceConfirmed",
+	AttribGuided:              "Guided",
+	AttribGuidedElectrodermal: "GuidedElectrodermal",
+}
+
+// String returns the name of the attrib or its numeric value if it is unknown.
+func (a Attrib) String() string {
+	if name, ok := attribNames[a]; ok {
+		return name
+	}
+	return fmt.Sprintf("Attrib(%d)", int64(a))
+}
+
+// IsKnown reports whether the attrib is one of the values documented by Withings.
+func (a Attrib) IsKnown() bool {
+	_, ok := attribNames[a]
+	return ok
+}
+
+// IsAmbiguous reports whether the group was captured by a device but may belong to another user.
+func (a Attrib) IsAmbiguous() bool {
+	return a == AttribDeviceAmbiguous
+}
+
+// IsManual reports whether the group was entered by hand rather than captured by a device.
+func (a Attrib) IsManual() bool {
+	return a == AttribManual || a == AttribManualCreation
+}
+
+// IsDevice reports whether the group was captured by a device and is known to belong to the user.
+func (a Attrib) IsDevice() bool {
+	switch a {
+	case AttribDevice, AttribDeviceConfirmed, AttribAutoBloodPressure, AttribConfirmed, AttribGuided,
+		AttribGuidedElectrodermal:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+package gowithings
+
+import "fmt"
+
+// BodyPosition is the part of the body a measure was taken on or a device was worn on, as documented by Withings.
+type BodyPosition int64
+
+const (
+	BodyPositionRightWrist  BodyPosition = 0
+	BodyPositionLeftWrist   BodyPosition = 1
+	BodyPositionRightArm    BodyPosition = 2
+	BodyPositionLeftArm     BodyPosition = 3
+	BodyPositionRightFoot   BodyPosition = 4
+	BodyPositionLeftFoot    BodyPosition = 5
+	BodyPositionBetweenLegs BodyPosition = 6
+	BodyPositionLeftBody    BodyPosition = 8
+	BodyPositionRightBody   BodyPosition = 9
+	BodyPositionLeftLeg     BodyPosition = 10
+	BodyPositionRightLeg    BodyPosition = 11
+	BodyPositionTorso       BodyPosition = 12
+	BodyPositionLeftHand    BodyPosition = 13
+	BodyPositionRightHand   BodyPosition = 14
+)
+
+var bodyPositionNames = map[BodyPosition]string{
+	BodyPositionRightWrist:  "RightWrist",
+	BodyPositionLeftWrist:   "LeftWrist",
+	BodyPositionRightArm:    "RightArm",
+	BodyPositionLeftArm:     "LeftArm",
+	BodyPositionRightFoot:   "RightFoot",
+	BodyPositionLeftFoot:    "LeftFoot",
+	BodyPositionBetweenLegs: "BetweenLegs",
+	BodyPositionLeftBody:    "LeftBody",
+	BodyPositionRightBody:   "RightBody",
+	BodyPositionLeftLeg:     "LeftLeg",
+	BodyPositionRightLeg:    "RightLeg",
+	BodyPositionTorso:       "Torso",
+	BodyPositionLeftHand:    "LeftHand",
+	BodyPositionRightHand:   "RightHand",
+}
+
+// String returns the name of the position or its numeric value if it is unknown.
+func (p BodyPosition) String() string {
+	if name, ok := bodyPositionNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("BodyPosition(%d)", int64(p))
+}
+
+// IsKnown reports whether the position is one of the values documented by Withings.
+func (p BodyPosition) IsKnown() bool {
+	_, ok := bodyPositionNames[p]
+	return ok
+}
+
+// Measure type codes of the measures that are reported per body segment.
+const (
+	measureTypeFatFreeMass        = 173
+	measureTypeFatMass            = 174
+	measureTypeMuscleMassSegments = 175
+)
+
+// SegmentValues are the values of a single measure type keyed by the body segment they were measured on.
+type SegmentValues map[BodyPosition]float64
+
+// LeftArm returns the value for the left arm and whether it was measured.
+func (s SegmentValues) LeftArm() (float64, bool) {
+	v, ok := s[BodyPositionLeftArm]
+	return v, ok
+}
+
+// RightArm returns the value for the right arm and whether it was measured.
+func (s SegmentValues) RightArm() (float64, bool) {
+	v, ok := s[BodyPositionRightArm]
+	return v, ok
+}
+
+// LeftLeg returns the value for the left leg and whether it was measured.
+func (s SegmentValues) LeftLeg() (float64, bool) {
+	v, ok := s[BodyPositionLeftLeg]
+	return v, ok
+}
+
+// RightLeg returns the value for the right leg and whether it was measured.
+func (s SegmentValues) RightLeg() (float64, bool) {
+	v, ok := s[BodyPositionRightLeg]
+	return v, ok
+}
+
+// Torso returns the value for the torso and whether it was measured.
+func (s SegmentValues) Torso() (float64, bool) {
+	v, ok := s[BodyPositionTorso]
+	return v, ok
+}
+
+// balance returns the share of the left segment in the combined left and right value. 0.5 is perfectly balanced,
+// higher values lean left. The second value is false if either side was not measured or both are zero.
+func (s SegmentValues) balance(left, right BodyPosition) (float64, bool) {
+	l, lok := s[left]
+	r, rok := s[right]
+	if !lok || !rok || l+r == 0 {
+		return 0, false
+	}
+	return l / (l + r), true
+}
+
+// ArmBalance returns the share of the left arm in the combined arm value. See balance for details.
+func (s SegmentValues) ArmBalance() (float64, bool) {
+	return s.balance(BodyPositionLeftArm, BodyPositionRightArm)
+}
+
+// LegBalance returns the share of the left leg in the combined leg value. See balance for details.
+func (s SegmentValues) LegBalance() (float64, bool) {
+	return s.balance(BodyPositionLeftLeg, BodyPositionRightLeg)
+}
+
+// SegmentalComposition is the per segment body composition reported by Body Scan scales in kilograms.
+type SegmentalComposition struct {
+	MuscleMass  SegmentValues
+	FatMass     SegmentValues
+	FatFreeMass SegmentValues
+}
+
+// IsEmpty reports whether the composition has no segmental values.
+func (sc SegmentalComposition) IsEmpty() bool {
+	return len(sc.MuscleMass) == 0 && len(sc.FatMass) == 0 && len(sc.FatFreeMass) == 0
+}
+
+// SegmentalComposition builds the segmental composition from the measures of the group that carry a position.
+// Measures without a position are the whole body values and are ignored.
+func (mg MeasureGroup) SegmentalComposition() SegmentalComposition {
+	sc := SegmentalComposition{
+		MuscleMass:  SegmentValues{},
+		FatMass:     SegmentValues{},
+		FatFreeMass: SegmentValues{},
+	}
+
+	for _, m := range mg.Measures {
+		if m.Position == nil {
+			continue
+		}
+		switch m.Type {
+		case measureTypeMuscleMassSegments:
+			sc.MuscleMass[*m.Position] = m.ValueFloat64()
+		case measureTypeFatMass:
+			sc.FatMass[*m.Position] = m.ValueFloat64()
+		case measureTypeFatFreeMass:
+			sc.FatFreeMass[*m.Position] = m.ValueFloat64()
+		}
+	}
+
+	return sc
+}
@@ -2,7 +2,9 @@ package gowithings_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"testing"
 	"time"
@@ -10,8 +12,12 @@ import (
 	"github.com/canadyworkshop/gowithings"
 )
 
-// testClient builds a new test client from the test envs.
-func testClient() *gowithings.Client {
+// testClient builds a new test client from the test envs. Tests that talk to the API are skipped when the envs are
+// not set.
+func testClient(t *testing.T) *gowithings.Client {
+	if os.Getenv("GOWITHINGS_TEST_CLIENT_ID") == "" {
+		t.Skip("GOWITHINGS_TEST_CLIENT_ID not set")
+	}
 
 	c := gowithings.NewClient(gowithings.Config{
 		ClientID:     os.Getenv("GOWITHINGS_TEST_CLIENT_ID"),
@@ -24,7 +30,7 @@ func testClient() *gowithings.Client {
 
 func TestUserClient_GetMeasure(t *testing.T) {
 
-	c := testClient()
+	c := testClient(t)
 
 	u, err := c.DemoUser(context.Background())
 	if err != nil {
@@ -50,14 +56,14 @@ func TestUserClient_GetMeasure(t *testing.T) {
 
 	for _, g := range r.MeasureGroups {
 		for _, m := range g.Measures {
-			fmt.Printf("%v: %v\n", gowithings.MeasureTypesByKey[m.Type], m.Float64())
+			fmt.Printf("%v: %v\n", gowithings.MeasureTypesByKey[int(m.Type)], m.ValueFloat64())
 		}
 
 	}
 }
 
 func TestUserClient_GetAllMeasures(t *testing.T) {
-	c := testClient()
+	c := testClient(t)
 
 	u, err := c.DemoUser(context.Background())
 	if err != nil {
@@ -83,3 +89,115 @@ func TestUserClient_GetAllMeasures(t *testing.T) {
 
 	fmt.Println(len(r))
 }
+
+func TestAttrib(t *testing.T) {
+	tests := []struct {
+		attrib    gowithings.Attrib
+		ambiguous bool
+		manual    bool
+		known     bool
+	}{
+		{gowithings.AttribDevice, false, false, true},
+		{gowithings.AttribDeviceAmbiguous, true, false, true},
+		{gowithings.AttribManual, false, true, true},
+		{gowithings.AttribManualCreation, false, true, true},
+		{gowithings.AttribDeviceConfirmed, false, false, true},
+		{gowithings.Attrib(99), false, false, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.attrib.IsAmbiguous(); got != tt.ambiguous {
+			t.Errorf("%v.IsAmbiguous() = %v, want %v", tt.attrib, got, tt.ambiguous)
+		}
+		if got := tt.attrib.IsManual(); got != tt.manual {
+			t.Errorf("%v.IsManual() = %v, want %v", tt.attrib, got, tt.manual)
+		}
+		if got := tt.attrib.IsKnown(); got != tt.known {
+			t.Errorf("%v.IsKnown() = %v, want %v", tt.attrib, got, tt.known)
+		}
+	}
+
+	if got := gowithings.Attrib(99).String(); got != "Attrib(99)" {
+		t.Errorf("String() = %q, want %q", got, "Attrib(99)")
+	}
+}
+
+func TestMeasureGroup_SegmentalComposition(t *testing.T) {
+	// Positions use the codes documented by Withings: 0 right wrist, 2 right arm, 3 left arm, 10 left leg, 12 torso.
+	body := `{"grpid":1,"measures":[
+		{"type":175,"value":3200,"unit":-3,"position":3},
+		{"type":175,"value":3400,"unit":-3,"position":2},
+		{"type":175,"value":25000,"unit":-3,"position":12},
+		{"type":175,"value":900,"unit":-3,"position":0},
+		{"type":174,"value":1500,"unit":-3,"position":10},
+		{"type":174,"value":20000,"unit":-3},
+		{"type":1,"value":72000,"unit":-3}
+	]}`
+
+	var mg gowithings.MeasureGroup
+	if err := json.Unmarshal([]byte(body), &mg); err != nil {
+		t.Fatal(err)
+	}
+	if mg.Measures[5].Position != nil {
+		t.Errorf("whole body measure has position %v", *mg.Measures[5].Position)
+	}
+
+	sc := mg.SegmentalComposition()
+	if sc.IsEmpty() {
+		t.Fatal("composition is empty")
+	}
+	if v, ok := sc.MuscleMass.LeftArm(); !ok || v != 3.2 {
+		t.Errorf("MuscleMass.LeftArm() = %v, %v, want 3.2, true", v, ok)
+	}
+	if v, ok := sc.MuscleMass.RightArm(); !ok || v != 3.4 {
+		t.Errorf("MuscleMass.RightArm() = %v, %v, want 3.4, true", v, ok)
+	}
+	if v, ok := sc.MuscleMass[gowithings.BodyPositionRightWrist]; !ok || v != 0.9 {
+		t.Errorf("right wrist muscle mass = %v, %v, want 0.9, true", v, ok)
+	}
+	if v, ok := sc.MuscleMass.Torso(); !ok || v != 25 {
+		t.Errorf("MuscleMass.Torso() = %v, %v, want 25, true", v, ok)
+	}
+	if v, ok := sc.FatMass.LeftLeg(); !ok || v != 1.5 || len(sc.FatMass) != 1 {
+		t.Errorf("FatMass = %v, want only a 1.5 left leg value", sc.FatMass)
+	}
+	balance, ok := sc.MuscleMass.ArmBalance()
+	if !ok || balance < 0.48 || balance > 0.49 {
+		t.Errorf("ArmBalance() = %v, %v, want ~0.485, true", balance, ok)
+	}
+	if _, ok := sc.MuscleMass.LegBalance(); ok {
+		t.Error("LegBalance() reported a balance without leg values")
+	}
+}
+
+func TestGetMeasureParam_URLEncode(t *testing.T) {
+	tests := []struct {
+		category gowithings.MeasureCategory
+		want     string
+		wantErr  bool
+	}{
+		{0, "1", false},
+		{gowithings.MeasureCategoryRealMeasures, "1", false},
+		{gowithings.MeasureCategoryUserObjectives, "2", false},
+		{gowithings.MeasureCategory(3), "", true},
+	}
+
+	for _, tt := range tests {
+		param := gowithings.GetMeasureParam{MeasureTypes: []string{"1"}, Category: tt.category}
+		encoded, err := param.URLEncode()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("URLEncode() with category %v error = %v, wantErr %v", tt.category, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		values, err := url.ParseQuery(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := values.Get("category"); got != tt.want {
+			t.Errorf("category = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package gowithings
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// locationCache caches the locations loaded by loadLocation keyed by their IANA name. Names that fail to load are
+// cached as nil so the zone database is only consulted once per name.
+var locationCache sync.Map
+
+// loadLocation returns the location for the IANA zone name provided or nil if it is empty or cannot be loaded.
+// Thread Safe: YES
+func loadLocation(name string) *time.Location {
+	if name == "" {
+		return nil
+	}
+	if loc, ok := locationCache.Load(name); ok {
+		return loc.(*time.Location)
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		loc = nil
+	}
+	locationCache.Store(name, loc)
+	return loc
+}
+
+// resolveLocation returns the location of the first zone name that can be loaded, falling back to UTC.
+func resolveLocation(names ...string) *time.Location {
+	for _, name := range names {
+		if loc := loadLocation(name); loc != nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+// Location returns the location the group was measured in. The group timezone is used first, then the timezone of
+// the response the group was decoded from and UTC if neither can be loaded.
+func (mg MeasureGroup) Location() *time.Location {
+	return resolveLocation(mg.Timezone, mg.responseTimezone)
+}
+
+// LocalMeasuredAt returns the time of the measurement in the timezone the group was measured in.
+func (mg MeasureGroup) LocalMeasuredAt() time.Time {
+	return time.Unix(mg.Date, 0).In(mg.Location())
+}
+
+// CreatedAt returns the time the group was created in the timezone the group was measured in.
+func (mg MeasureGroup) CreatedAt() time.Time {
+	return time.Unix(mg.Created, 0).In(mg.Location())
+}
+
+// ModifiedAt returns the time the group was last modified in the timezone the group was measured in.
+func (mg MeasureGroup) ModifiedAt() time.Time {
+	return time.Unix(mg.Modified, 0).In(mg.Location())
+}
+
+// LocalDay returns midnight of the calendar day the group was measured on in the user's local timezone.
+func (mg MeasureGroup) LocalDay() time.Time {
+	t := mg.LocalMeasuredAt()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// MeasureDay is the set of measure groups measured on a single local calendar day.
+type MeasureDay struct {
+	// Day is midnight of the day in the timezone of the first group measured that day.
+	Day    time.Time
+	Groups []MeasureGroup
+}
+
+// Key returns the day formatted as YYYY-MM-DD.
+func (d MeasureDay) Key() string {
+	return d.Day.Format(time.DateOnly)
+}
+
+// GroupMeasuresByDay buckets the groups by the local calendar day they were measured on, so a morning weigh-in while
+// travelling lands on the day the user experienced rather than the day in the server's timezone. Days are returned in
+// ascending order and groups within a day are sorted by measurement time.
+func GroupMeasuresByDay(groups []MeasureGroup) []MeasureDay {
+	sorted := make([]MeasureGroup, len(groups))
+	copy(sorted, groups)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date < sorted[j].Date
+	})
+
+	days := make([]MeasureDay, 0)
+	index := make(map[string]int)
+	for _, mg := range sorted {
+		day := mg.LocalDay()
+		key := day.Format(time.DateOnly)
+		i, ok := index[key]
+		if !ok {
+			i = len(days)
+			index[key] = i
+			days = append(days, MeasureDay{Day: day})
+		}
+		days[i].Groups = append(days[i].Groups, mg)
+	}
+
+	sort.SliceStable(days, func(i, j int) bool {
+		return days[i].Key() < days[j].Key()
+	})
+	return days
+}
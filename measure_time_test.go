@@ -0,0 +1,81 @@
+package gowithings_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/canadyworkshop/gowithings"
+)
+
+func TestMeasureGroup_LocalMeasuredAt(t *testing.T) {
+	// 2024-03-10 07:30 in Tokyo is still 2024-03-09 in UTC.
+	date := time.Date(2024, 3, 9, 22, 30, 0, 0, time.UTC).Unix()
+
+	mg := gowithings.MeasureGroup{Date: date, Timezone: "Asia/Tokyo"}
+	got := mg.LocalMeasuredAt()
+	if got.Location().String() != "Asia/Tokyo" {
+		t.Errorf("location = %s, want Asia/Tokyo", got.Location())
+	}
+	if got.Hour() != 7 || got.Day() != 10 {
+		t.Errorf("LocalMeasuredAt() = %v, want 2024-03-10 07:30 JST", got)
+	}
+
+	mg.Timezone = "Not/AZone"
+	if got := mg.LocalMeasuredAt().Location(); got != time.UTC {
+		t.Errorf("location = %s, want UTC fallback", got)
+	}
+}
+
+func TestMeasureGroup_Location(t *testing.T) {
+	tests := []struct {
+		name         string
+		groupZone    string
+		responseZone string
+		wantLocation string
+	}{
+		{"group zone", "Asia/Tokyo", "Europe/Paris", "Asia/Tokyo"},
+		{"missing group zone falls back to the response zone", "", "Europe/Paris", "Europe/Paris"},
+		{"invalid group zone falls back to the response zone", "Not/AZone", "Europe/Paris", "Europe/Paris"},
+		{"no valid zone falls back to UTC", "Not/AZone", "", "UTC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := fmt.Sprintf(`{"status":0,"body":{"timezone":%q,"measuregrps":[{"grpid":1,"date":1700000000,"timezone":%q}]}}`,
+				tt.responseZone, tt.groupZone)
+			resp, err := gowithings.ParseMeasureResponse([]byte(body), false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			mg := resp.MeasureGroups[0]
+			if mg.Timezone != tt.groupZone {
+				t.Errorf("Timezone = %q, want %q as sent by the API", mg.Timezone, tt.groupZone)
+			}
+			if got := mg.LocalMeasuredAt().Location().String(); got != tt.wantLocation {
+				t.Errorf("location = %s, want %s", got, tt.wantLocation)
+			}
+		})
+	}
+}
+
+func TestGroupMeasuresByDay(t *testing.T) {
+	groups := []gowithings.MeasureGroup{
+		{GroupID: 3, Date: time.Date(2024, 3, 10, 18, 0, 0, 0, time.UTC).Unix(), Timezone: "America/New_York"},
+		{GroupID: 1, Date: time.Date(2024, 3, 9, 22, 30, 0, 0, time.UTC).Unix(), Timezone: "Asia/Tokyo"},
+		{GroupID: 2, Date: time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC).Unix(), Timezone: "UTC"},
+	}
+
+	days := gowithings.GroupMeasuresByDay(groups)
+	if len(days) != 1 {
+		t.Fatalf("got %d days, want 1", len(days))
+	}
+	if days[0].Key() != "2024-03-10" {
+		t.Errorf("Key() = %s, want 2024-03-10", days[0].Key())
+	}
+	for i, want := range []int64{1, 2, 3} {
+		if days[0].Groups[i].GroupID != want {
+			t.Errorf("Groups[%d].GroupID = %d, want %d", i, days[0].Groups[i].GroupID, want)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+package gowithings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// TokenStore loads and saves the tokens of users. Tokens are saved back whenever a fetch refreshed them, since
+// Withings invalidates the previous refresh token.
+type TokenStore interface {
+	LoadToken(ctx context.Context, userID int64) (RequestToken, error)
+	SaveToken(ctx context.Context, userID int64, token RequestToken) error
+}
+
+// saveRefreshedToken saves the token of the user client if it changed since it was loaded from the store.
+func saveRefreshedToken(ctx context.Context, tokens TokenStore, userID int64, loaded RequestToken, uc *UserClient) error {
+	refreshed := uc.GetToken()
+	if refreshed.RefreshToken == loaded.RefreshToken && refreshed.AccessToken == loaded.AccessToken {
+		return nil
+	}
+	if err := tokens.SaveToken(ctx, userID, refreshed); err != nil {
+		return fmt.Errorf("failed to save token of user %d: %w", userID, err)
+	}
+	return nil
+}
+
+// NotificationMeasureTypes are the measure types fetched for each appli that notifies about measures.
+var NotificationMeasureTypes = map[Appli][]string{
+	AppliWeight: {
+		MeasureTypes["Weight"], MeasureTypes["FatFreeMassKG"], MeasureTypes["FatRatio"],
+		MeasureTypes["FatMassWeight"], MeasureTypes["MuscleMass"], MeasureTypes["Hydration"],
+		MeasureTypes["BoneMass"], MeasureTypes["PulseWaveVelocity"], MeasureTypes["VisceralFat"],
+		MeasureTypes["ExtracellularWater"], MeasureTypes["IntracellularWater"], MeasureTypes["FatFreeMass"],
+		MeasureTypes["FatMass"], MeasureTypes["MuscleMassSegments"], MeasureTypes["BasalMetabolicRate"],
+		MeasureTypes["MetabolicAge"],
+	},
+	AppliTemperature: {
+		MeasureTypes["Temperature"], MeasureTypes["BodyTemperature"], MeasureTypes["SkinTemperature"],
+	},
+	AppliPressure: {
+		MeasureTypes["DiastolicBloodPressure"], MeasureTypes["SystolicBloodPressure"], MeasureTypes["HeartPulse"],
+		MeasureTypes["SP02"],
+	},
+}
+
+// FetchResult is the data fetched in response to a notification. Only the fields matching the appli of the
+// notification are set.
+type FetchResult struct {
+	Notification   Notification
+	Measures       []MeasureGroup
+	Activities     []Activity
+	SleepSummaries []SleepSummary
+	Sleep          []SleepSegment
+	ECGs           []ECG
+}
+
+// NotificationFetcherConfig defines the configuration of a new notification fetcher.
+type NotificationFetcherConfig struct {
+	// Client is used to create the user clients.
+	Client *Client
+	// Tokens resolves the token of the user of each notification.
+	Tokens TokenStore
+	// Sink receives the data fetched for each notification.
+	Sink func(context.Context, FetchResult) error
+	// OnError, when set, is called when a notification could not be fetched or delivered.
+	OnError func(Notification, error)
+	// CoalesceDelay is how long notifications for the same user and appli are collected before a single fetch
+	// covering all of them is made. Zero fetches every notification immediately.
+	CoalesceDelay time.Duration
+	// SleepFields are the high frequency series fetched for sleep notifications. None are fetched if empty.
+	SleepFields []SleepField
+}
+
+// NotificationFetcher fetches the data a notification is about and delivers it to a sink. Its Handle method can be
+// passed directly to NewNotificationHandler.
+type NotificationFetcher struct {
+	config  NotificationFetcherConfig
+	ctx     context.Context
+	cancel  context.CancelFunc
+	pending map[pendingKey]*pendingFetch
+	wg      sync.WaitGroup
+	sync.Mutex
+}
+
+// pendingKey identifies the notifications that are coalesced together.
+type pendingKey struct {
+	userID int64
+	appli  Appli
+}
+
+// pendingFetch is a coalesced notification waiting to be fetched.
+type pendingFetch struct {
+	notification Notification
+	timer        *time.Timer
+}
+
+// NewNotificationFetcher creates a new notification fetcher based on the configuration provided.
+func NewNotificationFetcher(config NotificationFetcherConfig) *NotificationFetcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &NotificationFetcher{
+		config:  config,
+		ctx:     ctx,
+		cancel:  cancel,
+		pending: make(map[pendingKey]*pendingFetch),
+	}
+}
+
+// notificationRange returns the time range a notification covers. Notifications carrying a single date cover that
+// day.
+func notificationRange(n Notification) (time.Time, time.Time, error) {
+	if !n.StartDate.IsZero() && !n.EndDate.IsZero() {
+		return n.StartDate, n.EndDate, nil
+	}
+	if n.Date != "" {
+		day, err := time.Parse(time.DateOnly, n.Date)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid notification date %q: %w", n.Date, err)
+		}
+		return day, day.Add(24*time.Hour - time.Second), nil
+	}
+	return time.Time{}, time.Time{}, errors.New("notification has no date range")
+}
+
+// mergeNotifications widens the range of the first notification to also cover the second.
+func mergeNotifications(a, b Notification) Notification {
+	aStart, aEnd, aErr := notificationRange(a)
+	bStart, bEnd, bErr := notificationRange(b)
+	switch {
+	case aErr != nil:
+		return b
+	case bErr != nil:
+		return a
+	}
+
+	if bStart.Before(aStart) {
+		aStart = bStart
+	}
+	if bEnd.After(aEnd) {
+		aEnd = bEnd
+	}
+	a.StartDate, a.EndDate, a.Date = aStart, aEnd, ""
+	return a
+}
+
+// Handle fetches the data of the notification and delivers it to the sink, coalescing it with other notifications
+// for the same user and appli when a coalesce delay is configured. Errors are reported to OnError.
+// Thread Safe: YES
+func (f *NotificationFetcher) Handle(ctx context.Context, n Notification) {
+	if f.config.CoalesceDelay <= 0 {
+		f.process(ctx, n)
+		return
+	}
+
+	key := pendingKey{userID: n.UserID, appli: n.Appli}
+
+	f.Lock()
+	defer f.Unlock()
+
+	if p, ok := f.pending[key]; ok {
+		p.notification = mergeNotifications(p.notification, n)
+		return
+	}
+
+	f.wg.Add(1)
+	p := &pendingFetch{notification: n}
+	p.timer = time.AfterFunc(f.config.CoalesceDelay, func() {
+		defer f.wg.Done()
+		f.Lock()
+		n := p.notification
+		delete(f.pending, key)
+		f.Unlock()
+		f.process(f.ctx, n)
+	})
+	f.pending[key] = p
+}
+
+// Close fetches the notifications still waiting to be coalesced and waits for every fetch to complete.
+func (f *NotificationFetcher) Close() {
+	f.Lock()
+	for key, p := range f.pending {
+		if p.timer.Stop() {
+			delete(f.pending, key)
+			n := p.notification
+			go func() {
+				defer f.wg.Done()
+				f.process(f.ctx, n)
+			}()
+		}
+	}
+	f.Unlock()
+
+	f.wg.Wait()
+	f.cancel()
+}
+
+// process fetches the notification and delivers the result, reporting errors to OnError.
+func (f *NotificationFetcher) process(ctx context.Context, n Notification) {
+	err := f.deliver(ctx, n)
+	if err != nil && f.config.OnError != nil {
+		f.config.OnError(n, err)
+	}
+}
+
+// deliver resolves the user of the notification, fetches its data and passes it to the sink.
+func (f *NotificationFetcher) deliver(ctx context.Context, n Notification) error {
+	if f.config.Client == nil || f.config.Tokens == nil {
+		return errors.New("notification fetcher requires a client and a token store")
+	}
+
+	token, err := f.config.Tokens.LoadToken(ctx, n.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to load token of user %d: %w", n.UserID, err)
+	}
+
+	uc := f.config.Client.NewUserClient(token)
+	result, fetchErr := f.Fetch(ctx, uc, n)
+
+	// Save the token even if the fetch failed since it may have been refreshed before failing.
+	if err := saveRefreshedToken(ctx, f.config.Tokens, n.UserID, token, uc); err != nil {
+		return err
+	}
+	if fetchErr != nil {
+		return fetchErr
+	}
+
+	if f.config.Sink == nil {
+		return nil
+	}
+	if err := f.config.Sink(ctx, result); err != nil {
+		return fmt.Errorf("failed to deliver %s notification of user %d: %w", n.Appli, n.UserID, err)
+	}
+	return nil
+}
+
+// Fetch fetches the data the notification is about with the user client provided.
+func (f *NotificationFetcher) Fetch(ctx context.Context, uc *UserClient, n Notification) (FetchResult, error) {
+	result := FetchResult{Notification: n}
+
+	start, end, err := notificationRange(n)
+	if err != nil {
+		return result, err
+	}
+
+	switch n.Appli {
+	case AppliWeight, AppliTemperature, AppliPressure:
+		result.Measures, err = uc.GetAllMeasures(ctx, GetMeasureParam{
+			MeasureTypes: NotificationMeasureTypes[n.Appli],
+			StartDate:    start,
+			EndDate:      end,
+		})
+	case AppliActivity:
+		result.Activities, err = uc.GetActivity(ctx, ActivityParam{StartDate: start, EndDate: end})
+	case AppliSleep:
+		result.SleepSummaries, err = uc.GetSleepSummaries(ctx, SleepSummaryParam{StartDate: start, EndDate: end})
+		if err == nil && end.After(start) {
+			result.Sleep, err = uc.GetSleep(ctx, start, end, f.config.SleepFields)
+		}
+	case AppliECG:
+		result.ECGs, err = uc.ListECGs(ctx, ECGListParam{StartDate: start, EndDate: end})
+	default:
+		return result, fmt.Errorf("no fetch defined for appli %s", n.Appli)
+	}
+	if err != nil {
+		return result, fmt.Errorf("failed to fetch %s notification of user %d: %w", n.Appli, n.UserID, err)
+	}
+
+	return result, nil
+}
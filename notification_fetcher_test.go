@@ -0,0 +1,70 @@
+package gowithings_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/canadyworkshop/gowithings"
+)
+
+// missingTokens is a token store that knows no user.
+type missingTokens struct{}
+
+func (missingTokens) LoadToken(ctx context.Context, userID int64) (gowithings.RequestToken, error) {
+	return gowithings.RequestToken{}, errors.New("unknown user")
+}
+
+func (missingTokens) SaveToken(ctx context.Context, userID int64, token gowithings.RequestToken) error {
+	return nil
+}
+
+func TestNotificationFetcher_Coalesce(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		failed []gowithings.Notification
+	)
+	f := gowithings.NewNotificationFetcher(gowithings.NotificationFetcherConfig{
+		Client:        gowithings.NewClient(gowithings.Config{}),
+		Tokens:        missingTokens{},
+		CoalesceDelay: time.Hour,
+		OnError: func(n gowithings.Notification, err error) {
+			mu.Lock()
+			failed = append(failed, n)
+			mu.Unlock()
+		},
+	})
+
+	f.Handle(context.Background(), gowithings.Notification{
+		UserID: 1, Appli: gowithings.AppliWeight, StartDate: time.Unix(2000, 0), EndDate: time.Unix(2100, 0),
+	})
+	f.Handle(context.Background(), gowithings.Notification{
+		UserID: 1, Appli: gowithings.AppliWeight, StartDate: time.Unix(1000, 0), EndDate: time.Unix(1100, 0),
+	})
+	f.Handle(context.Background(), gowithings.Notification{
+		UserID: 2, Appli: gowithings.AppliWeight, StartDate: time.Unix(1000, 0), EndDate: time.Unix(1100, 0),
+	})
+
+	// Close flushes the pending notifications without waiting for the coalesce delay.
+	f.Close()
+
+	if len(failed) != 2 {
+		t.Fatalf("got %d fetches, want 2", len(failed))
+	}
+	for _, n := range failed {
+		if n.UserID == 1 && (n.StartDate.Unix() != 1000 || n.EndDate.Unix() != 2100) {
+			t.Errorf("user 1 range = %d-%d, want 1000-2100", n.StartDate.Unix(), n.EndDate.Unix())
+		}
+	}
+}
+
+func TestNotificationMeasureTypes(t *testing.T) {
+	for appli := gowithings.Appli(0); appli < 100; appli++ {
+		n := gowithings.Notification{Appli: appli}
+		if n.IsMeasure() && len(gowithings.NotificationMeasureTypes[appli]) == 0 {
+			t.Errorf("measure appli %s has no measure types to fetch", appli)
+		}
+	}
+}
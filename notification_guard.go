@@ -0,0 +1,206 @@
+package gowithings
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CallbackSecretParam is the query parameter of the callback URL that carries the secret of a subscription.
+const CallbackSecretParam = "secret"
+
+// DefaultUserRatePeriod is the period UserRateLimit applies to when none is provided.
+const DefaultUserRatePeriod = time.Hour
+
+// NewCallbackSecret generates a random secret to embed in a callback URL with SecretCallbackURL.
+func NewCallbackSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// SecretCallbackURL returns the callback URL with the secret added as the CallbackSecretParam query parameter. The
+// returned URL is the one to subscribe with.
+func SecretCallbackURL(callbackURL, secret string) (string, error) {
+	u, err := url.Parse(callbackURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid callback URL: %w", err)
+	}
+	q := u.Query()
+	q.Set(CallbackSecretParam, secret)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// notificationKey identifies duplicate notifications.
+type notificationKey struct {
+	userID    int64
+	appli     Appli
+	startDate int64
+	endDate   int64
+	date      string
+}
+
+// userRate counts the notifications of a user in the current rate period.
+type userRate struct {
+	start time.Time
+	count int
+}
+
+// notificationGuard applies the hardening options of a notification handler. Withings notifications are not signed
+// so anyone knowing the callback URL could otherwise trigger fetches.
+type notificationGuard struct {
+	config    NotificationHandlerConfig
+	seen      map[notificationKey]time.Time
+	rates     map[int64]*userRate
+	lastPrune time.Time
+	sync.Mutex
+}
+
+// newNotificationGuard creates the guard for the configuration provided.
+func newNotificationGuard(config NotificationHandlerConfig) *notificationGuard {
+	if config.UserRatePeriod <= 0 {
+		config.UserRatePeriod = DefaultUserRatePeriod
+	}
+	return &notificationGuard{
+		config: config,
+		seen:   make(map[notificationKey]time.Time),
+		rates:  make(map[int64]*userRate),
+	}
+}
+
+// sourceAddr returns the address the request came from.
+func (g *notificationGuard) sourceAddr(r *http.Request) (netip.Addr, error) {
+	host := r.RemoteAddr
+	if g.config.TrustForwardedFor {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			// Only the rightmost entry was added by the trusted proxy, the others are controlled by the client.
+			hops := strings.Split(forwarded, ",")
+			host = strings.TrimSpace(hops[len(hops)-1])
+		}
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return addr, err
+	}
+	return addr.Unmap(), nil
+}
+
+// trusted reports whether the request comes from a trusted network and carries a valid secret. The notification is
+// zero for validation probes.
+func (g *notificationGuard) trusted(r *http.Request, n Notification) bool {
+	if len(g.config.TrustedNetworks) > 0 {
+		addr, err := g.sourceAddr(r)
+		if err != nil {
+			return false
+		}
+		trusted := false
+		for _, network := range g.config.TrustedNetworks {
+			if network.Contains(addr) {
+				trusted = true
+				break
+			}
+		}
+		if !trusted {
+			return false
+		}
+	}
+
+	if g.config.ValidSecret != nil {
+		secret := r.URL.Query().Get(CallbackSecretParam)
+		if secret == "" || !g.config.ValidSecret(secret, n) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// admit reports whether the notification should be delivered, recording it for deduplication and rate limiting
+// when it is. Notifications of unknown users, duplicates and notifications over the rate limit of their user are
+// not admitted.
+// Thread Safe: YES
+func (g *notificationGuard) admit(n Notification) bool {
+	if g.config.KnownUser != nil && !g.config.KnownUser(n.UserID) {
+		return false
+	}
+
+	g.Lock()
+	defer g.Unlock()
+
+	now := time.Now()
+	g.prune(now)
+
+	key := notificationKey{n.UserID, n.Appli, n.StartDate.Unix(), n.EndDate.Unix(), n.Date}
+	if g.config.DedupWindow > 0 {
+		if at, ok := g.seen[key]; ok && now.Sub(at) < g.config.DedupWindow {
+			return false
+		}
+	}
+
+	if g.config.UserRateLimit > 0 {
+		rate, ok := g.rates[n.UserID]
+		if !ok || now.Sub(rate.start) >= g.config.UserRatePeriod {
+			rate = &userRate{start: now}
+			g.rates[n.UserID] = rate
+		}
+		if rate.count >= g.config.UserRateLimit {
+			return false
+		}
+		rate.count++
+	}
+
+	if g.config.DedupWindow > 0 {
+		g.seen[key] = now
+	}
+	return true
+}
+
+// forget reverts the records made by admit for a notification that could not be delivered, so the retry Withings
+// makes is not rejected as a duplicate.
+// Thread Safe: YES
+func (g *notificationGuard) forget(n Notification) {
+	g.Lock()
+	defer g.Unlock()
+
+	delete(g.seen, notificationKey{n.UserID, n.Appli, n.StartDate.Unix(), n.EndDate.Unix(), n.Date})
+	if rate, ok := g.rates[n.UserID]; ok && rate.count > 0 {
+		rate.count--
+	}
+}
+
+// prune drops the expired deduplication and rate records, at most once per window.
+// Thread Safe: NO
+func (g *notificationGuard) prune(now time.Time) {
+	interval := g.config.DedupWindow
+	if g.config.UserRatePeriod > interval {
+		interval = g.config.UserRatePeriod
+	}
+	if now.Sub(g.lastPrune) < interval {
+		return
+	}
+	g.lastPrune = now
+
+	for key, at := range g.seen {
+		if now.Sub(at) >= g.config.DedupWindow {
+			delete(g.seen, key)
+		}
+	}
+	for userID, rate := range g.rates {
+		if now.Sub(rate.start) >= g.config.UserRatePeriod {
+			delete(g.rates, userID)
+		}
+	}
+}
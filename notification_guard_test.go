@@ -0,0 +1,114 @@
+package gowithings_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/canadyworkshop/gowithings"
+)
+
+func TestSecretCallbackURL(t *testing.T) {
+	got, err := gowithings.SecretCallbackURL("https://example.com/withings?user=1", "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "https://example.com/withings?secret=abc&user=1"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	a, err := gowithings.NewCallbackSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := gowithings.NewCallbackSecret()
+	if a == "" || a == b {
+		t.Errorf("secrets %q and %q should be distinct and not empty", a, b)
+	}
+}
+
+func TestNotificationHandler_Trusted(t *testing.T) {
+	h := gowithings.NewNotificationHandler(gowithings.NotificationHandlerConfig{
+		TrustedNetworks:   []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")},
+		TrustForwardedFor: true,
+		ValidSecret: func(secret string, n gowithings.Notification) bool {
+			return secret == "s3cret"
+		},
+	}, func(ctx context.Context, n gowithings.Notification) {})
+	defer h.Close()
+
+	values := url.Values{"userid": {"1"}, "appli": {"1"}, "startdate": {"1700000000"}, "enddate": {"1700000100"}}
+	tests := []struct {
+		name      string
+		target    string
+		forwarded string
+		want      int
+	}{
+		{"valid", "/withings?secret=s3cret", "10.1.2.3", http.StatusOK},
+		{"bad secret", "/withings?secret=wrong", "10.1.2.3", http.StatusForbidden},
+		{"no secret", "/withings", "10.1.2.3", http.StatusForbidden},
+		{"untrusted network", "/withings?secret=s3cret", "192.168.1.1", http.StatusForbidden},
+		{"spoofed forwarded for", "/withings?secret=s3cret", "10.1.2.3, 192.168.1.1", http.StatusForbidden},
+		{"proxy chain", "/withings?secret=s3cret", "192.168.1.1, 10.1.2.3", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(values.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.Header.Set("X-Forwarded-For", tt.forwarded)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodHead, "/withings", nil))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("untrusted probe status = %d, want 403", rec.Code)
+	}
+}
+
+func TestNotificationHandler_Admit(t *testing.T) {
+	received := make(chan gowithings.Notification, 10)
+	h := gowithings.NewNotificationHandler(gowithings.NotificationHandlerConfig{
+		KnownUser:     func(userID int64) bool { return userID != 666 },
+		DedupWindow:   time.Minute,
+		UserRateLimit: 2,
+	}, func(ctx context.Context, n gowithings.Notification) {
+		received <- n
+	})
+
+	posts := []url.Values{
+		{"userid": {"1"}, "appli": {"1"}, "startdate": {"1700000000"}, "enddate": {"1700000100"}},
+		// Duplicate of the first notification.
+		{"userid": {"1"}, "appli": {"1"}, "startdate": {"1700000000"}, "enddate": {"1700000100"}},
+		{"userid": {"1"}, "appli": {"16"}, "date": {"2024-03-10"}},
+		// Over the rate limit of user 1.
+		{"userid": {"1"}, "appli": {"16"}, "date": {"2024-03-11"}},
+		{"userid": {"666"}, "appli": {"1"}, "startdate": {"1700000000"}, "enddate": {"1700000100"}},
+		{"userid": {"2"}, "appli": {"1"}, "startdate": {"1700000000"}, "enddate": {"1700000100"}},
+	}
+	for i, values := range posts {
+		if code := postNotification(h, values); code != http.StatusOK {
+			t.Errorf("post %d status = %d, want 200", i, code)
+		}
+	}
+	h.Close()
+	close(received)
+
+	var got []string
+	for n := range received {
+		got = append(got, n.Appli.String()+"/"+n.Values.Get("userid"))
+	}
+	if want := "Weight/1 Activity/1 Weight/2"; strings.Join(got, " ") != want {
+		t.Errorf("handled %v, want %s", got, want)
+	}
+}
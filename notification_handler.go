@@ -0,0 +1,291 @@
+package gowithings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Notification is a notification sent by Withings to a subscribed callback URL.
+type Notification struct {
+	UserID int64
+	Appli  Appli
+	// StartDate and EndDate bound the data that changed. They are zero for notifications that do not carry them.
+	StartDate time.Time
+	EndDate   time.Time
+	// Date is the day that changed as YYYY-MM-DD, sent instead of a range for some applis such as activity.
+	Date string
+	// DeviceID is the device the notification relates to, if any.
+	DeviceID string
+	// Action is the user action of AppliUser notifications, such as unlink or delete.
+	Action string
+	// ReceivedAt is the time the notification was received.
+	ReceivedAt time.Time
+	// Values are the raw form values of the notification.
+	Values url.Values
+}
+
+// IsMeasure reports whether the notification is about data fetched with GetMeasure. Glucose notifications are not
+// since MeasureTypes has no glucose measure type.
+func (n Notification) IsMeasure() bool {
+	switch n.Appli {
+	case AppliWeight, AppliTemperature, AppliPressure:
+		return true
+	}
+	return false
+}
+
+// IsBedEvent reports whether the notification is a bed in or bed out event of a sleep sensor.
+func (n Notification) IsBedEvent() bool {
+	return n.Appli == AppliBedIn || n.Appli == AppliBedOut
+}
+
+// parseUnix parses a unix timestamp form value, returning the zero time if it is empty.
+func parseUnix(values url.Values, key string) (time.Time, error) {
+	s := values.Get(key)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	ts, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s %q: %w", key, s, err)
+	}
+	return time.Unix(ts, 0), nil
+}
+
+// ParseNotification parses the form values of a notification sent by Withings.
+func ParseNotification(values url.Values) (Notification, error) {
+	n := Notification{
+		Date:       values.Get("date"),
+		DeviceID:   values.Get("deviceid"),
+		Action:     values.Get("action"),
+		ReceivedAt: time.Now(),
+		Values:     values,
+	}
+
+	userID, err := strconv.ParseInt(values.Get("userid"), 10, 64)
+	if err != nil {
+		return n, fmt.Errorf("invalid userid %q: %w", values.Get("userid"), err)
+	}
+	n.UserID = userID
+
+	appli, err := strconv.ParseInt(values.Get("appli"), 10, 64)
+	if err != nil {
+		return n, fmt.Errorf("invalid appli %q: %w", values.Get("appli"), err)
+	}
+	n.Appli = Appli(appli)
+
+	if n.StartDate, err = parseUnix(values, "startdate"); err != nil {
+		return n, err
+	}
+	if n.EndDate, err = parseUnix(values, "enddate"); err != nil {
+		return n, err
+	}
+
+	return n, nil
+}
+
+const (
+	// DefaultNotificationQueueSize is the number of notifications queued when no queue size is provided.
+	DefaultNotificationQueueSize = 100
+	// DefaultNotificationWorkers is the number of notifications handled concurrently when no worker count is
+	// provided.
+	DefaultNotificationWorkers = 1
+)
+
+// NotificationHandlerConfig defines the configuration of a new notification handler.
+type NotificationHandlerConfig struct {
+	// QueueSize is the number of notifications that can wait to be handled. Defaults to
+	// DefaultNotificationQueueSize.
+	QueueSize int
+	// Workers is the number of notifications handled concurrently. Defaults to DefaultNotificationWorkers.
+	Workers int
+	// EnqueueTimeout is how long a request waits for room in a full queue before it is answered with 503 so Withings
+	// retries later. Zero answers immediately.
+	EnqueueTimeout time.Duration
+
+	// ValidSecret, when set, rejects requests with 403 unless the CallbackSecretParam query parameter of the
+	// callback URL holds a secret it accepts for the notification. The notification is zero for the validation
+	// probes. See SecretCallbackURL.
+	ValidSecret func(secret string, n Notification) bool
+	// TrustedNetworks, when set, rejects requests from any other source address with 403.
+	TrustedNetworks []netip.Prefix
+	// TrustForwardedFor takes the source address from the last entry of the X-Forwarded-For header, the one added by
+	// the proxy, for handlers behind a single proxy.
+	TrustForwardedFor bool
+	// KnownUser, when set, drops the notifications of users it does not know.
+	KnownUser func(userID int64) bool
+	// DedupWindow drops notifications with the same user, appli and dates as one delivered within the window.
+	DedupWindow time.Duration
+	// UserRateLimit caps the number of notifications delivered per user every UserRatePeriod, which defaults to
+	// DefaultUserRatePeriod. Zero disables the cap.
+	UserRateLimit  int
+	UserRatePeriod time.Duration
+}
+
+// NotificationHandler is an http.Handler receiving Withings notifications. It answers the validation probes
+// Withings sends when subscribing, parses notifications and passes them to a handler function asynchronously so
+// Withings always gets a quick answer.
+type NotificationHandler struct {
+	config NotificationHandlerConfig
+	guard  *notificationGuard
+	handle func(context.Context, Notification)
+	queue  chan Notification
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	// done is closed by Close to release the requests waiting for room in the queue, which senders tracks.
+	done    chan struct{}
+	senders sync.WaitGroup
+	closed  bool
+	sync.RWMutex
+}
+
+// NewNotificationHandler creates a new notification handler calling handle for every notification received. Handle
+// is called from the worker goroutines of the handler.
+func NewNotificationHandler(config NotificationHandlerConfig, handle func(context.Context, Notification)) *NotificationHandler {
+	if config.QueueSize <= 0 {
+		config.QueueSize = DefaultNotificationQueueSize
+	}
+	if config.Workers <= 0 {
+		config.Workers = DefaultNotificationWorkers
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h := &NotificationHandler{
+		config: config,
+		guard:  newNotificationGuard(config),
+		handle: handle,
+		queue:  make(chan Notification, config.QueueSize),
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	for i := 0; i < config.Workers; i++ {
+		h.wg.Add(1)
+		go func() {
+			defer h.wg.Done()
+			for n := range h.queue {
+				h.handle(h.ctx, n)
+			}
+		}()
+	}
+
+	return h
+}
+
+// errQueueFull is returned by enqueue when the notification could not be queued in time.
+var errQueueFull = errors.New("notification queue is full")
+
+// errHandlerClosed is returned by enqueue once the handler is closed.
+var errHandlerClosed = errors.New("notification handler is closed")
+
+// enqueue queues the notification, waiting up to the enqueue timeout for room in the queue. The lock is only held
+// to register the sender so Close is not blocked by a full queue.
+// Thread Safe: YES
+func (h *NotificationHandler) enqueue(ctx context.Context, n Notification) error {
+	h.RLock()
+	if h.closed {
+		h.RUnlock()
+		return errHandlerClosed
+	}
+	h.senders.Add(1)
+	h.RUnlock()
+	defer h.senders.Done()
+
+	select {
+	case h.queue <- n:
+		return nil
+	default:
+	}
+	if h.config.EnqueueTimeout <= 0 {
+		return errQueueFull
+	}
+
+	timer := time.NewTimer(h.config.EnqueueTimeout)
+	defer timer.Stop()
+	select {
+	case h.queue <- n:
+		return nil
+	case <-timer.C:
+		return errQueueFull
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-h.done:
+		return errHandlerClosed
+	}
+}
+
+// ServeHTTP answers the HEAD and GET validation probes with 200 and queues POSTed notifications. Requests that are
+// not valid notifications are answered with 400, untrusted requests with 403 and 503 is returned when the queue is
+// full. Notifications dropped by the KnownUser, DedupWindow and UserRateLimit options are answered with 200 so
+// Withings does not retry them.
+func (h *NotificationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodHead, http.MethodGet:
+		if !h.guard.trusted(r, Notification{}) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	case http.MethodPost:
+	default:
+		w.Header().Set("Allow", "HEAD, GET, POST")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+	n, err := ParseNotification(r.PostForm)
+	if err != nil {
+		http.Error(w, "invalid notification", http.StatusBadRequest)
+		return
+	}
+	if !h.guard.trusted(r, n) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	if !h.guard.admit(n) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if err := h.enqueue(r.Context(), n); err != nil {
+		h.guard.forget(n)
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// Close stops accepting notifications and waits for the queued notifications to be handled. Requests waiting for
+// room in the queue are answered with 503. The context passed to the handler function is cancelled once the queued
+// notifications are handled.
+func (h *NotificationHandler) Close() {
+	h.Lock()
+	if h.closed {
+		h.Unlock()
+		return
+	}
+	h.closed = true
+	close(h.done)
+	h.Unlock()
+
+	// The queue is only closed once no request can send on it anymore.
+	h.senders.Wait()
+	close(h.queue)
+	h.wg.Wait()
+	h.cancel()
+}
@@ -0,0 +1,141 @@
+package gowithings_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/canadyworkshop/gowithings"
+)
+
+// postNotification posts the notification form values to the handler and returns the response status.
+func postNotification(h http.Handler, values url.Values) int {
+	req := httptest.NewRequest(http.MethodPost, "/withings", strings.NewReader(values.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestNotificationHandler(t *testing.T) {
+	received := make(chan gowithings.Notification, 1)
+	h := gowithings.NewNotificationHandler(gowithings.NotificationHandlerConfig{}, func(ctx context.Context, n gowithings.Notification) {
+		received <- n
+	})
+	defer h.Close()
+
+	for _, method := range []string{http.MethodHead, http.MethodGet} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(method, "/withings", nil))
+		if rec.Code != http.StatusOK {
+			t.Errorf("%s status = %d, want 200", method, rec.Code)
+		}
+	}
+
+	code := postNotification(h, url.Values{
+		"userid":    {"1234"},
+		"appli":     {"1"},
+		"startdate": {"1700000000"},
+		"enddate":   {"1700000100"},
+	})
+	if code != http.StatusOK {
+		t.Fatalf("POST status = %d, want 200", code)
+	}
+
+	select {
+	case n := <-received:
+		if n.UserID != 1234 || n.Appli != gowithings.AppliWeight || !n.IsMeasure() {
+			t.Errorf("got %+v, want a weight notification for user 1234", n)
+		}
+		if n.EndDate.Unix() != 1700000100 {
+			t.Errorf("EndDate = %v, want unix 1700000100", n.EndDate)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("notification was not handled")
+	}
+
+	if code := postNotification(h, url.Values{"appli": {"1"}}); code != http.StatusBadRequest {
+		t.Errorf("POST without userid status = %d, want 400", code)
+	}
+}
+
+func TestNotificationHandler_QueueFull(t *testing.T) {
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	h := gowithings.NewNotificationHandler(gowithings.NotificationHandlerConfig{QueueSize: 1}, func(ctx context.Context, n gowithings.Notification) {
+		started <- struct{}{}
+		<-release
+	})
+	defer h.Close()
+	defer close(release)
+
+	values := url.Values{"userid": {"1"}, "appli": {"16"}, "date": {"2024-03-10"}}
+
+	// The first notification occupies the only worker and the second fills the queue.
+	if code := postNotification(h, values); code != http.StatusOK {
+		t.Fatalf("first status = %d, want 200", code)
+	}
+	<-started
+	if code := postNotification(h, values); code != http.StatusOK {
+		t.Fatalf("second status = %d, want 200", code)
+	}
+	if code := postNotification(h, values); code != http.StatusServiceUnavailable {
+		t.Errorf("third status = %d, want 503", code)
+	}
+}
+
+func TestNotificationHandler_CloseReleasesWaitingRequests(t *testing.T) {
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	h := gowithings.NewNotificationHandler(gowithings.NotificationHandlerConfig{
+		QueueSize:      1,
+		Workers:        1,
+		EnqueueTimeout: time.Minute,
+	}, func(ctx context.Context, n gowithings.Notification) {
+		started <- struct{}{}
+		<-release
+	})
+
+	values := url.Values{"userid": {"1"}, "appli": {"16"}, "date": {"2024-03-10"}}
+
+	// The first notification occupies the worker, the second fills the queue and the third waits for room.
+	if code := postNotification(h, values); code != http.StatusOK {
+		t.Fatalf("first status = %d, want 200", code)
+	}
+	<-started
+	if code := postNotification(h, values); code != http.StatusOK {
+		t.Fatalf("second status = %d, want 200", code)
+	}
+	waiting := make(chan int, 1)
+	go func() {
+		waiting <- postNotification(h, values)
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	closed := make(chan struct{})
+	go func() {
+		h.Close()
+		close(closed)
+	}()
+
+	select {
+	case code := <-waiting:
+		if code != http.StatusServiceUnavailable {
+			t.Errorf("waiting status = %d, want 503", code)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Close did not release the waiting request")
+	}
+
+	close(release)
+	<-started
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close did not return")
+	}
+}
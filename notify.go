@@ -0,0 +1,205 @@
+package gowithings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Appli is the kind of data a notification subscription is about.
+type Appli int64
+
+const (
+	AppliWeight              Appli = 1
+	AppliTemperature         Appli = 2
+	AppliPressure            Appli = 4
+	AppliActivity            Appli = 16
+	AppliSleep               Appli = 44
+	AppliUser                Appli = 46
+	AppliBedIn               Appli = 50
+	AppliBedOut              Appli = 51
+	AppliInflateDone         Appli = 52
+	AppliNoAccountAssociated Appli = 53
+	AppliECG                 Appli = 54
+	AppliECGFailed           Appli = 55
+	AppliGlucose             Appli = 58
+)
+
+var appliNames = map[Appli]string{
+	AppliWeight:              "Weight",
+	AppliTemperature:         "Temperature",
+	AppliPressure:            "Pressure",
+	AppliActivity:            "Activity",
+	AppliSleep:               "Sleep",
+	AppliUser:                "User",
+	AppliBedIn:               "BedIn",
+	AppliBedOut:              "BedOut",
+	AppliInflateDone:         "InflateDone",
+	AppliNoAccountAssociated: "NoAccountAssociated",
+	AppliECG:                 "ECG",
+	AppliECGFailed:           "ECGFailed",
+	AppliGlucose:             "Glucose",
+}
+
+// String returns the name of the appli or its numeric value if it is unknown.
+func (a Appli) String() string {
+	if name, ok := appliNames[a]; ok {
+		return name
+	}
+	return fmt.Sprintf("Appli(%d)", int64(a))
+}
+
+// IsKnown reports whether the appli is one of the values documented by Withings.
+func (a Appli) IsKnown() bool {
+	_, ok := appliNames[a]
+	return ok
+}
+
+// Subscription is a notification subscription of the user.
+type Subscription struct {
+	Appli       Appli  `json:"appli"`
+	CallbackURL string `json:"callbackurl"`
+	Comment     string `json:"comment"`
+	Expires     int64  `json:"expires"`
+}
+
+// ExpiresAt returns the time the subscription expires, or the zero time if it does not.
+func (s Subscription) ExpiresAt() time.Time {
+	if s.Expires == 0 {
+		return time.Time{}
+	}
+	return time.Unix(s.Expires, 0)
+}
+
+// subscriptionsResponse is the raw response of a list subscriptions API request.
+type subscriptionsResponse struct {
+	Profiles []Subscription `json:"profiles"`
+}
+
+// notifyValues returns the values shared by the notify actions.
+func notifyValues(action, callbackURL string, appli Appli) url.Values {
+	v := url.Values{}
+	v.Add("action", action)
+	if callbackURL != "" {
+		v.Add("callbackurl", callbackURL)
+	}
+	if appli != 0 {
+		v.Add("appli", strconv.FormatInt(int64(appli), 10))
+	}
+	return v
+}
+
+// subscriptionValues returns the values of an action on the subscription of the callback URL to the appli, both of
+// which are required.
+func subscriptionValues(action, callbackURL string, appli Appli) (url.Values, error) {
+	if callbackURL == "" {
+		return nil, errors.New("no callback URL provided")
+	}
+	if appli == 0 {
+		return nil, errors.New("no appli provided")
+	}
+	return notifyValues(action, callbackURL, appli), nil
+}
+
+// subscribeValues returns the values of a subscribe request.
+func subscribeValues(callbackURL string, appli Appli, comment string) (url.Values, error) {
+	v, err := subscriptionValues("subscribe", callbackURL, appli)
+	if err != nil {
+		return nil, err
+	}
+	if comment != "" {
+		v.Add("comment", comment)
+	}
+	return v, nil
+}
+
+// updateSubscriptionValues returns the values of an update request. The new appli defaults to the current one.
+func updateSubscriptionValues(callbackURL string, appli Appli, newCallbackURL string, newAppli Appli, comment string) (url.Values, error) {
+	v, err := subscriptionValues("update", callbackURL, appli)
+	if err != nil {
+		return nil, err
+	}
+	if newCallbackURL == "" {
+		return nil, errors.New("no new callback URL provided")
+	}
+	if newAppli == 0 {
+		newAppli = appli
+	}
+
+	v.Add("new_callbackurl", newCallbackURL)
+	v.Add("new_appli", strconv.FormatInt(int64(newAppli), 10))
+	if comment != "" {
+		v.Add("comment", comment)
+	}
+	return v, nil
+}
+
+// Subscribe subscribes the callback URL to notifications of the appli provided for the user. Withings checks the
+// callback URL is reachable before accepting the subscription and returns ErrCallbackUnreachable or
+// ErrInvalidCallbackURL when it is not.
+func (c *UserClient) Subscribe(ctx context.Context, callbackURL string, appli Appli, comment string) error {
+	v, err := subscribeValues(callbackURL, appli, comment)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.post(ctx, NotifyURL, v.Encode(), nil); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", appli, err)
+	}
+	return nil
+}
+
+// ListSubscriptions returns the notification subscriptions of the user for the appli provided, or for every appli
+// if it is zero.
+func (c *UserClient) ListSubscriptions(ctx context.Context, appli Appli) ([]Subscription, error) {
+	resp := subscriptionsResponse{}
+	if _, err := c.post(ctx, NotifyURL, notifyValues("list", "", appli).Encode(), &resp); err != nil {
+		return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+	return resp.Profiles, nil
+}
+
+// GetSubscription returns the subscription of the callback URL to the appli provided. ErrSubscriptionNotFound is
+// returned if there is none.
+func (c *UserClient) GetSubscription(ctx context.Context, callbackURL string, appli Appli) (Subscription, error) {
+	subscription := Subscription{}
+	v, err := subscriptionValues("get", callbackURL, appli)
+	if err != nil {
+		return subscription, err
+	}
+
+	if _, err := c.post(ctx, NotifyURL, v.Encode(), &subscription); err != nil {
+		return subscription, fmt.Errorf("failed to get subscription to %s: %w", appli, err)
+	}
+	return subscription, nil
+}
+
+// UpdateSubscription moves the subscription of the callback URL to the appli provided to the new callback URL and
+// appli.
+func (c *UserClient) UpdateSubscription(ctx context.Context, callbackURL string, appli Appli, newCallbackURL string, newAppli Appli, comment string) error {
+	v, err := updateSubscriptionValues(callbackURL, appli, newCallbackURL, newAppli, comment)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.post(ctx, NotifyURL, v.Encode(), nil); err != nil {
+		return fmt.Errorf("failed to update subscription to %s: %w", appli, err)
+	}
+	return nil
+}
+
+// RevokeSubscription removes the subscription of the callback URL to the appli provided.
+func (c *UserClient) RevokeSubscription(ctx context.Context, callbackURL string, appli Appli) error {
+	v, err := subscriptionValues("revoke", callbackURL, appli)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.post(ctx, NotifyURL, v.Encode(), nil); err != nil {
+		return fmt.Errorf("failed to revoke subscription to %s: %w", appli, err)
+	}
+	return nil
+}
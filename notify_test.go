@@ -0,0 +1,150 @@
+package gowithings_test
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/canadyworkshop/gowithings"
+)
+
+const callbackURL = "https://example.com/withings"
+
+func TestNotifyValues(t *testing.T) {
+	tests := []struct {
+		name        string
+		action      string
+		callbackURL string
+		appli       gowithings.Appli
+		want        url.Values
+	}{
+		{
+			name:   "list every appli",
+			action: "list",
+			want:   url.Values{"action": {"list"}},
+		},
+		{
+			name:   "list one appli",
+			action: "list",
+			appli:  gowithings.AppliSleep,
+			want:   url.Values{"action": {"list"}, "appli": {"44"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := gowithings.NotifyValues(tt.action, tt.callbackURL, tt.appli)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("values = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriptionValues(t *testing.T) {
+	tests := []struct {
+		name        string
+		callbackURL string
+		appli       gowithings.Appli
+		want        url.Values
+		wantErr     bool
+	}{
+		{
+			name:        "valid",
+			callbackURL: callbackURL,
+			appli:       gowithings.AppliWeight,
+			want:        url.Values{"action": {"revoke"}, "callbackurl": {callbackURL}, "appli": {"1"}},
+		},
+		{name: "missing callback URL", appli: gowithings.AppliWeight, wantErr: true},
+		{name: "missing appli", callbackURL: callbackURL, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := gowithings.SubscriptionValues("revoke", tt.callbackURL, tt.appli)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("values = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscribeValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		want    url.Values
+	}{
+		{
+			name:    "with comment",
+			comment: "scale",
+			want:    url.Values{"action": {"subscribe"}, "callbackurl": {callbackURL}, "appli": {"1"}, "comment": {"scale"}},
+		},
+		{
+			name: "without comment",
+			want: url.Values{"action": {"subscribe"}, "callbackurl": {callbackURL}, "appli": {"1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := gowithings.SubscribeValues(callbackURL, gowithings.AppliWeight, tt.comment)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("values = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := gowithings.SubscribeValues("", gowithings.AppliWeight, ""); err == nil {
+		t.Error("expected an error without a callback URL")
+	}
+}
+
+func TestUpdateSubscriptionValues(t *testing.T) {
+	const newCallbackURL = "https://example.com/withings/v2"
+
+	tests := []struct {
+		name           string
+		newCallbackURL string
+		newAppli       gowithings.Appli
+		want           url.Values
+		wantErr        bool
+	}{
+		{
+			name:           "new appli",
+			newCallbackURL: newCallbackURL,
+			newAppli:       gowithings.AppliTemperature,
+			want: url.Values{"action": {"update"}, "callbackurl": {callbackURL}, "appli": {"1"},
+				"new_callbackurl": {newCallbackURL}, "new_appli": {"2"}},
+		},
+		{
+			name:           "new appli defaults to the current one",
+			newCallbackURL: newCallbackURL,
+			want: url.Values{"action": {"update"}, "callbackurl": {callbackURL}, "appli": {"1"},
+				"new_callbackurl": {newCallbackURL}, "new_appli": {"1"}},
+		},
+		{name: "missing new callback URL", newAppli: gowithings.AppliTemperature, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := gowithings.UpdateSubscriptionValues(callbackURL, gowithings.AppliWeight, tt.newCallbackURL, tt.newAppli, "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("values = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := gowithings.UpdateSubscriptionValues("", gowithings.AppliWeight, newCallbackURL, 0, ""); err == nil {
+		t.Error("expected an error without the current callback URL")
+	}
+}
@@ -0,0 +1,120 @@
+package gowithings
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// IsObjective reports whether the group is a target set by the user rather than a real measurement.
+func (mg MeasureGroup) IsObjective() bool {
+	return mg.Category == MeasureCategoryUserObjectives
+}
+
+// ObjectiveValue is a target value set by the user at a point in time.
+type ObjectiveValue struct {
+	GroupID int64
+	Value   float64
+	SetAt   time.Time
+}
+
+// Objective is the target the user set for a single measure type, along with every previous target for it.
+type Objective struct {
+	// MeasureType is the numeric measure type, see MeasureTypesByKey.
+	MeasureType int
+	// Name is the name of the measure type, see MeasureTypes.
+	Name string
+	// Current is the most recently set target.
+	Current ObjectiveValue
+	// History holds every target for the measure type in ascending order, including Current.
+	History []ObjectiveValue
+}
+
+// Objectives is the set of targets of a user sorted by measure type.
+type Objectives []Objective
+
+// Get returns the objective for the numeric measure type provided.
+func (o Objectives) Get(measureType int) (Objective, bool) {
+	for _, objective := range o {
+		if objective.MeasureType == measureType {
+			return objective, true
+		}
+	}
+	return Objective{}, false
+}
+
+// Weight returns the weight objective of the user.
+func (o Objectives) Weight() (Objective, bool) {
+	return o.Get(1)
+}
+
+// FatRatio returns the fat ratio objective of the user.
+func (o Objectives) FatRatio() (Objective, bool) {
+	return o.Get(6)
+}
+
+// newObjectives builds the objectives from groups of the user objectives category.
+func newObjectives(groups []MeasureGroup) Objectives {
+	byType := make(map[int]*Objective)
+	for _, mg := range groups {
+		if !mg.IsObjective() {
+			continue
+		}
+		for _, m := range mg.Measures {
+			measureType := int(m.Type)
+			objective, ok := byType[measureType]
+			if !ok {
+				objective = &Objective{
+					MeasureType: measureType,
+					Name:        MeasureTypesByKey[measureType],
+				}
+				byType[measureType] = objective
+			}
+			objective.History = append(objective.History, ObjectiveValue{
+				GroupID: mg.GroupID,
+				Value:   m.ValueFloat64(),
+				SetAt:   mg.LocalMeasuredAt(),
+			})
+		}
+	}
+
+	objectives := make(Objectives, 0, len(byType))
+	for _, objective := range byType {
+		sort.SliceStable(objective.History, func(i, j int) bool {
+			return objective.History[i].SetAt.Before(objective.History[j].SetAt)
+		})
+		objective.Current = objective.History[len(objective.History)-1]
+		objectives = append(objectives, *objective)
+	}
+	sort.Slice(objectives, func(i, j int) bool {
+		return objectives[i].MeasureType < objectives[j].MeasureType
+	})
+
+	return objectives
+}
+
+// GetObjectives returns the targets the user has set, such as a target weight, with the history of each.
+func (c *UserClient) GetObjectives(ctx context.Context) (Objectives, error) {
+	// The types are sorted so the request is the same on every call.
+	codes := make([]int, 0, len(MeasureTypesByKey))
+	for code := range MeasureTypesByKey {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	measureTypes := make([]string, len(codes))
+	for i, code := range codes {
+		measureTypes[i] = strconv.Itoa(code)
+	}
+
+	groups, err := c.GetAllMeasures(ctx, GetMeasureParam{
+		MeasureTypes: measureTypes,
+		Category:     MeasureCategoryUserObjectives,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get objectives: %w", err)
+	}
+
+	return newObjectives(groups), nil
+}
@@ -0,0 +1,127 @@
+package gowithings_test
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/canadyworkshop/gowithings"
+)
+
+func TestNewObjectives(t *testing.T) {
+	objective := func(id, date int64, measures ...gowithings.Measure) gowithings.MeasureGroup {
+		return gowithings.MeasureGroup{
+			GroupID:  id,
+			Date:     date,
+			Category: gowithings.MeasureCategoryUserObjectives,
+			Measures: measures,
+		}
+	}
+	weight := func(kg int64) gowithings.Measure { return gowithings.Measure{Type: 1, Value: kg, Unit: 0} }
+	fat := func(percent int64) gowithings.Measure { return gowithings.Measure{Type: 6, Value: percent, Unit: 0} }
+
+	tests := []struct {
+		name    string
+		groups  []gowithings.MeasureGroup
+		want    map[int][]int64
+		current map[int]float64
+	}{
+		{
+			name: "out of order groups",
+			groups: []gowithings.MeasureGroup{
+				objective(2, 200, weight(75)),
+				objective(3, 300, weight(72)),
+				objective(1, 100, weight(80)),
+			},
+			want:    map[int][]int64{1: {1, 2, 3}},
+			current: map[int]float64{1: 72},
+		},
+		{
+			name: "real measures are skipped",
+			groups: []gowithings.MeasureGroup{
+				objective(1, 100, weight(80)),
+				{GroupID: 2, Date: 200, Category: gowithings.MeasureCategoryRealMeasures, Measures: []gowithings.Measure{weight(90)}},
+			},
+			want:    map[int][]int64{1: {1}},
+			current: map[int]float64{1: 80},
+		},
+		{
+			name: "several types in one group",
+			groups: []gowithings.MeasureGroup{
+				objective(2, 200, fat(20)),
+				objective(1, 100, weight(80), fat(25)),
+			},
+			want:    map[int][]int64{1: {1}, 6: {1, 2}},
+			current: map[int]float64{1: 80, 6: 20},
+		},
+		{
+			name: "no objectives",
+			groups: []gowithings.MeasureGroup{
+				{GroupID: 1, Date: 100, Category: gowithings.MeasureCategoryRealMeasures, Measures: []gowithings.Measure{weight(90)}},
+			},
+			want: map[int][]int64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objectives := gowithings.NewObjectives(tt.groups)
+			if len(objectives) != len(tt.want) {
+				t.Fatalf("got %d objectives, want %d", len(objectives), len(tt.want))
+			}
+			for i := 1; i < len(objectives); i++ {
+				if objectives[i-1].MeasureType >= objectives[i].MeasureType {
+					t.Errorf("objectives are not sorted by measure type: %+v", objectives)
+				}
+			}
+
+			for measureType, ids := range tt.want {
+				o, ok := objectives.Get(measureType)
+				if !ok {
+					t.Fatalf("no objective for type %d", measureType)
+				}
+				if len(o.History) != len(ids) {
+					t.Fatalf("type %d history = %+v, want groups %v", measureType, o.History, ids)
+				}
+				for i, id := range ids {
+					if o.History[i].GroupID != id {
+						t.Errorf("type %d history %d = group %d, want %d", measureType, i, o.History[i].GroupID, id)
+					}
+				}
+				if o.Current.Value != tt.current[measureType] {
+					t.Errorf("type %d current = %v, want %v", measureType, o.Current.Value, tt.current[measureType])
+				}
+			}
+		})
+	}
+}
+
+func TestUserClient_GetObjectives(t *testing.T) {
+	api := &fakeAPI{respond: func(url.Values) string {
+		return `{"measuregrps":[{"grpid":1,"date":100,"category":2,"measures":[{"value":70,"type":1,"unit":0}]}]}`
+	}}
+	uc := newTestUserClient(t, api)
+
+	for i := 0; i < 2; i++ {
+		objectives, err := uc.GetObjectives(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if weight, ok := objectives.Weight(); !ok || weight.Current.Value != 70 {
+			t.Errorf("weight objective = %+v, want 70", weight)
+		}
+	}
+
+	requests := api.Requests()
+	if requests[0].Get("category") != "2" {
+		t.Errorf("category = %s, want 2", requests[0].Get("category"))
+	}
+	types := requests[0].Get("meastypes")
+	if !strings.HasPrefix(types, "1,4,5,6,") {
+		t.Errorf("meastypes = %s, want the type codes in ascending order", types)
+	}
+	if other := requests[1].Get("meastypes"); other != types {
+		t.Errorf("meastypes changed between calls: %s then %s", types, other)
+	}
+}
@@ -0,0 +1,55 @@
+package gowithings
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter spaces requests evenly so that no more than the configured number of requests are made per minute. A
+// nil rateLimiter does not limit requests.
+type rateLimiter struct {
+	interval time.Duration
+	next     time.Time
+	sync.Mutex
+}
+
+// newRateLimiter creates a rate limiter for the number of requests per minute provided. If perMinute is not
+// positive nil is returned and requests are not limited.
+func newRateLimiter(perMinute int) *rateLimiter {
+	if perMinute <= 0 {
+		return nil
+	}
+	return &rateLimiter{interval: time.Minute / time.Duration(perMinute)}
+}
+
+// wait blocks until the next request is allowed or the context is done.
+// Thread Safe: YES
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.Lock()
+	now := time.Now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next = slot.Add(l.interval)
+	l.Unlock()
+
+	delay := slot.Sub(now)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
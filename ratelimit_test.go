@@ -0,0 +1,56 @@
+package gowithings_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/canadyworkshop/gowithings"
+)
+
+func TestRateLimiter(t *testing.T) {
+	// 600 requests per minute spaces requests 100ms apart.
+	wait := gowithings.RateLimiterWait(600)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond || elapsed > time.Second {
+		t.Errorf("3 requests took %v, want about 200ms", elapsed)
+	}
+}
+
+func TestRateLimiter_Cancel(t *testing.T) {
+	wait := gowithings.RateLimiterWait(1)
+	if err := wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("wait returned %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("cancelled wait took %v", elapsed)
+	}
+}
+
+func TestRateLimiter_Unlimited(t *testing.T) {
+	wait := gowithings.RateLimiterWait(0)
+	start := time.Now()
+	for i := 0; i < 100; i++ {
+		if err := wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("unlimited waits took %v", elapsed)
+	}
+}
@@ -0,0 +1,81 @@
+package gowithings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// sign computes the signature v2 of the action. The signed values are the action, the client ID and the nonce or
+// timestamp, joined with commas in the alphabetical order of their names.
+func (client *Client) sign(action, nonce string) string {
+	return genHMACSHA256String(client.config.ClientSecret, fmt.Sprintf("%s,%s,%s", action, client.config.ClientID, nonce))
+}
+
+// SignValues returns a copy of the values with the action, client ID, nonce and signature of a request signed with
+// the signature v2 protocol added.
+func (client *Client) SignValues(action, nonce string, values url.Values) url.Values {
+	signed := url.Values{}
+	for key, value := range values {
+		signed[key] = append([]string(nil), value...)
+	}
+	signed.Set("action", action)
+	signed.Set("client_id", client.config.ClientID)
+	signed.Set("nonce", nonce)
+	signed.Set("signature", client.sign(action, nonce))
+	return signed
+}
+
+// postForm performs a form encoded POST of the values provided to the API URL without user authentication and
+// decodes the body of the response into out.
+func (client *Client) postForm(ctx context.Context, apiURL string, values url.Values, out interface{}) error {
+	if err := client.limiter.wait(ctx); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, strings.NewReader(values.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create new request: %w", err)
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	return decodeAPIResponse(body, out)
+}
+
+// SignedRequest performs the action on the API URL with a request signed with the signature v2 protocol and decodes
+// the body of the response into out. A fresh nonce is fetched for the request, and the request is retried once with
+// a new nonce if the API rejects the signature or the nonce.
+func (client *Client) SignedRequest(ctx context.Context, apiURL, action string, values url.Values, out interface{}) error {
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		var nonce string
+		nonce, err = client.getNonce(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get nonce: %w", err)
+		}
+
+		err = client.postForm(ctx, apiURL, client.SignValues(action, nonce, values), out)
+		if !errors.Is(err, ErrInvalidNonce) && !errors.Is(err, ErrInvalidSignature) {
+			break
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("signed %s request failed: %w", action, err)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package gowithings_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/canadyworkshop/gowithings"
+)
+
+func TestClient_SignValues(t *testing.T) {
+	client := gowithings.NewClient(gowithings.Config{ClientID: "client", ClientSecret: "secret"})
+
+	values := url.Values{"scope_oauth2": {"user.metrics"}}
+	signed := client.SignValues("getdemoaccess", "fixed-nonce", values)
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("getdemoaccess,client,fixed-nonce"))
+	want := hex.EncodeToString(mac.Sum(nil))
+
+	if got := signed.Get("signature"); got != want {
+		t.Errorf("signature = %s, want %s", got, want)
+	}
+	if signed.Get("action") != "getdemoaccess" || signed.Get("client_id") != "client" || signed.Get("nonce") != "fixed-nonce" {
+		t.Errorf("signed values = %v", signed)
+	}
+	if signed.Get("scope_oauth2") != "user.metrics" {
+		t.Errorf("signed values lost scope_oauth2: %v", signed)
+	}
+	if _, ok := values["signature"]; ok {
+		t.Error("SignValues modified the values provided")
+	}
+}
+
+func TestClient_SignedRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		// statuses are the statuses answered to the successive signed requests, the last one being repeated.
+		statuses   []int
+		wantNonces int
+		wantErr    error
+	}{
+		{"success", []int{0}, 1, nil},
+		{"invalid nonce is retried", []int{2553, 0}, 2, nil},
+		{"invalid signature is retried", []int{2555, 0}, 2, nil},
+		{"retried only once", []int{2553}, 2, gowithings.ErrInvalidNonce},
+		{"other errors are not retried", []int{2554}, 1, &gowithings.APIError{Status: 2554}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu     sync.Mutex
+				nonces int
+				signed []string
+			)
+			client := newTestClient(t, gowithings.Config{ClientID: "client", ClientSecret: "secret"},
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if err := r.ParseForm(); err != nil {
+						t.Error(err)
+					}
+					mu.Lock()
+					defer mu.Unlock()
+
+					if r.URL.Path == "/v2/signature" {
+						nonces++
+						fmt.Fprintf(w, `{"status":0,"body":{"nonce":"nonce-%d"}}`, nonces)
+						return
+					}
+					signed = append(signed, r.PostForm.Get("nonce"))
+					status := tt.statuses[min(len(signed), len(tt.statuses))-1]
+					fmt.Fprintf(w, `{"status":%d,"body":{"user":{"code":"code"}}}`, status)
+				}))
+
+			out := struct {
+				User struct {
+					Code string `json:"code"`
+				} `json:"user"`
+			}{}
+			err := client.SignedRequest(context.Background(), gowithings.UserV2URL, "activate", url.Values{}, &out)
+
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("SignedRequest() error = %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SignedRequest() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && out.User.Code != "code" {
+				t.Errorf("out = %+v, want the decoded body", out)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if nonces != tt.wantNonces {
+				t.Errorf("fetched %d nonces, want %d", nonces, tt.wantNonces)
+			}
+			for i, nonce := range signed {
+				if want := fmt.Sprintf("nonce-%d", i+1); nonce != want {
+					t.Errorf("request %d signed with %s, want %s", i, nonce, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package gowithings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// SleepMaxWindow is the longest time range the API returns high frequency sleep data for in a single request.
+const SleepMaxWindow = 24 * time.Hour
+
+// SleepState is the sleep state of the user during a sleep segment.
+type SleepState int64
+
+const (
+	SleepStateAwake       SleepState = 0
+	SleepStateLight       SleepState = 1
+	SleepStateDeep        SleepState = 2
+	SleepStateREM         SleepState = 3
+	SleepStateManual      SleepState = 4
+	SleepStateUnspecified SleepState = 5
+)
+
+// String returns the name of the sleep state or its numeric value if it is unknown.
+func (s SleepState) String() string {
+	switch s {
+	case SleepStateAwake:
+		return "Awake"
+	case SleepStateLight:
+		return "Light"
+	case SleepStateDeep:
+		return "Deep"
+	case SleepStateREM:
+		return "REM"
+	case SleepStateManual:
+		return "Manual"
+	case SleepStateUnspecified:
+		return "Unspecified"
+	}
+	return fmt.Sprintf("SleepState(%d)", int64(s))
+}
+
+// IsAsleep reports whether the state is one of the sleep phases.
+func (s SleepState) IsAsleep() bool {
+	return s == SleepStateLight || s == SleepStateDeep || s == SleepStateREM
+}
+
+// SleepField is a high frequency series that can be requested alongside the sleep states.
+type SleepField string
+
+const (
+	SleepFieldHR       SleepField = "hr"
+	SleepFieldRR       SleepField = "rr"
+	SleepFieldSnoring  SleepField = "snoring"
+	SleepFieldSDNN     SleepField = "sdnn_1"
+	SleepFieldRMSSD    SleepField = "rmssd"
+	SleepFieldMovement SleepField = "mvt_score"
+)
+
+// AllSleepFields is every high frequency sleep series.
+var AllSleepFields = []SleepField{
+	SleepFieldHR, SleepFieldRR, SleepFieldSnoring, SleepFieldSDNN, SleepFieldRMSSD, SleepFieldMovement,
+}
+
+// TimeValue is a single value of a time series.
+type TimeValue struct {
+	Time  time.Time
+	Value float64
+}
+
+// decodeTimeSeries converts a series keyed by unix timestamps into a slice sorted by time.
+func decodeTimeSeries(series map[string]float64) ([]TimeValue, error) {
+	if len(series) == 0 {
+		return nil, nil
+	}
+
+	values := make([]TimeValue, 0, len(series))
+	for key, value := range series {
+		ts, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid series timestamp %q: %w", key, err)
+		}
+		values = append(values, TimeValue{Time: time.Unix(ts, 0), Value: value})
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Time.Before(values[j].Time)
+	})
+	return values, nil
+}
+
+// sleepResponse is the raw response of a get sleep API request.
+type sleepResponse struct {
+	Series []sleepSeries `json:"series"`
+}
+
+// sleepSeries is a raw sleep segment with its high frequency series keyed by unix timestamp.
+type sleepSeries struct {
+	StartDate    int64              `json:"startdate"`
+	EndDate      int64              `json:"enddate"`
+	State        SleepState         `json:"state"`
+	Model        int64              `json:"model"`
+	ModelID      int64              `json:"model_id"`
+	HashDeviceID string             `json:"hash_deviceid"`
+	HR           map[string]float64 `json:"hr"`
+	RR           map[string]float64 `json:"rr"`
+	Snoring      map[string]float64 `json:"snoring"`
+	SDNN         map[string]float64 `json:"sdnn_1"`
+	RMSSD        map[string]float64 `json:"rmssd"`
+	Movement     map[string]float64 `json:"mvt_score"`
+}
+
+// SleepSegment is a period of a single sleep state along with the high frequency series measured during it. Series
+// that were not requested or not measured are nil.
+type SleepSegment struct {
+	Start        time.Time
+	End          time.Time
+	State        SleepState
+	Model        int64
+	ModelID      int64
+	HashDeviceID string
+
+	// HR is the heart rate in beats per minute.
+	HR []TimeValue
+	// RR is the respiration rate in breaths per minute.
+	RR []TimeValue
+	// Snoring is the time spent snoring in seconds.
+	Snoring []TimeValue
+	// SDNN is the standard deviation of the NN intervals in milliseconds.
+	SDNN []TimeValue
+	// RMSSD is the root mean square of successive differences of the NN intervals in milliseconds.
+	RMSSD []TimeValue
+	// Movement is the movement score.
+	Movement []TimeValue
+}
+
+// Duration returns the length of the segment.
+func (s SleepSegment) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// segment converts the raw series into a sleep segment.
+func (s sleepSeries) segment() (SleepSegment, error) {
+	segment := SleepSegment{
+		Start:        time.Unix(s.StartDate, 0),
+		End:          time.Unix(s.EndDate, 0),
+		State:        s.State,
+		Model:        s.Model,
+		ModelID:      s.ModelID,
+		HashDeviceID: s.HashDeviceID,
+	}
+
+	series := []struct {
+		raw map[string]float64
+		out *[]TimeValue
+	}{
+		{s.HR, &segment.HR},
+		{s.RR, &segment.RR},
+		{s.Snoring, &segment.Snoring},
+		{s.SDNN, &segment.SDNN},
+		{s.RMSSD, &segment.RMSSD},
+		{s.Movement, &segment.Movement},
+	}
+	for _, v := range series {
+		values, err := decodeTimeSeries(v.raw)
+		if err != nil {
+			return segment, err
+		}
+		*v.out = values
+	}
+
+	return segment, nil
+}
+
+// sleepSegments converts the raw series of one or more responses into segments sorted by start time. Series
+// returned by more than one response are only included once.
+func sleepSegments(series []sleepSeries) ([]SleepSegment, error) {
+	type segmentKey struct {
+		start, end   int64
+		state        SleepState
+		hashDeviceID string
+	}
+	seen := make(map[segmentKey]bool)
+
+	segments := make([]SleepSegment, 0, len(series))
+	for _, s := range series {
+		key := segmentKey{s.StartDate, s.EndDate, s.State, s.HashDeviceID}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		segment, err := s.segment()
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, segment)
+	}
+
+	sort.SliceStable(segments, func(i, j int) bool {
+		return segments[i].Start.Before(segments[j].Start)
+	})
+	return segments, nil
+}
+
+// GetSleep returns the sleep state segments between start and end with the high frequency series of the fields
+// provided. Ranges longer than SleepMaxWindow are split into several requests and segments returned by more than
+// one of them are only included once. Segments are sorted by start time.
+func (c *UserClient) GetSleep(ctx context.Context, start, end time.Time, fields []SleepField) ([]SleepSegment, error) {
+	if !start.Before(end) {
+		return nil, errors.New("start must be before end")
+	}
+
+	series := make([]sleepSeries, 0)
+	for _, w := range splitTimeRange(start, end, SleepMaxWindow) {
+		v := url.Values{}
+		v.Add("action", "get")
+		v.Add("startdate", strconv.FormatInt(w.start.Unix(), 10))
+		v.Add("enddate", strconv.FormatInt(w.end.Unix(), 10))
+		if len(fields) > 0 {
+			v.Add("data_fields", joinFields(fields))
+		}
+
+		resp := sleepResponse{}
+		if _, err := c.post(ctx, SleepV2URL, v.Encode(), &resp); err != nil {
+			return nil, fmt.Errorf("failed to get sleep from %s: %w", w.start.Format(time.RFC3339), err)
+		}
+		series = append(series, resp.Series...)
+	}
+
+	return sleepSegments(series)
+}
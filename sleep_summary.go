@@ -0,0 +1,214 @@
+package gowithings
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// SleepSummaryField is a field of the sleep summary that can be requested from the API.
+type SleepSummaryField string
+
+const (
+	SleepSummaryFieldBreathingDisturbancesIntensity SleepSummaryField = "breathing_disturbances_intensity"
+	SleepSummaryFieldDeepSleepDuration              SleepSummaryField = "deepsleepduration"
+	SleepSummaryFieldDurationToSleep                SleepSummaryField = "durationtosleep"
+	SleepSummaryFieldDurationToWakeup               SleepSummaryField = "durationtowakeup"
+	SleepSummaryFieldHRAverage                      SleepSummaryField = "hr_average"
+	SleepSummaryFieldHRMax                          SleepSummaryField = "hr_max"
+	SleepSummaryFieldHRMin                          SleepSummaryField = "hr_min"
+	SleepSummaryFieldLightSleepDuration             SleepSummaryField = "lightsleepduration"
+	SleepSummaryFieldNightEvents                    SleepSummaryField = "night_events"
+	SleepSummaryFieldOutOfBedCount                  SleepSummaryField = "out_of_bed_count"
+	SleepSummaryFieldREMSleepDuration               SleepSummaryField = "remsleepduration"
+	SleepSummaryFieldRRAverage                      SleepSummaryField = "rr_average"
+	SleepSummaryFieldRRMax                          SleepSummaryField = "rr_max"
+	SleepSummaryFieldRRMin                          SleepSummaryField = "rr_min"
+	SleepSummaryFieldSleepEfficiency                SleepSummaryField = "sleep_efficiency"
+	SleepSummaryFieldSleepLatency                   SleepSummaryField = "sleep_latency"
+	SleepSummaryFieldSleepScore                     SleepSummaryField = "sleep_score"
+	SleepSummaryFieldSnoring                        SleepSummaryField = "snoring"
+	SleepSummaryFieldSnoringEpisodeCount            SleepSummaryField = "snoringepisodecount"
+	SleepSummaryFieldTotalSleepTime                 SleepSummaryField = "total_sleep_time"
+	SleepSummaryFieldTotalTimeInBed                 SleepSummaryField = "total_timeinbed"
+	SleepSummaryFieldWakeupCount                    SleepSummaryField = "wakeupcount"
+	SleepSummaryFieldWakeupDuration                 SleepSummaryField = "wakeupduration"
+	SleepSummaryFieldWakeupLatency                  SleepSummaryField = "wakeup_latency"
+	SleepSummaryFieldWASO                           SleepSummaryField = "waso"
+)
+
+// AllSleepSummaryFields is every field of the sleep summary.
+var AllSleepSummaryFields = []SleepSummaryField{
+	SleepSummaryFieldBreathingDisturbancesIntensity, SleepSummaryFieldDeepSleepDuration,
+	SleepSummaryFieldDurationToSleep, SleepSummaryFieldDurationToWakeup, SleepSummaryFieldHRAverage,
+	SleepSummaryFieldHRMax, SleepSummaryFieldHRMin, SleepSummaryFieldLightSleepDuration, SleepSummaryFieldNightEvents,
+	SleepSummaryFieldOutOfBedCount, SleepSummaryFieldREMSleepDuration, SleepSummaryFieldRRAverage,
+	SleepSummaryFieldRRMax, SleepSummaryFieldRRMin, SleepSummaryFieldSleepEfficiency, SleepSummaryFieldSleepLatency,
+	SleepSummaryFieldSleepScore, SleepSummaryFieldSnoring, SleepSummaryFieldSnoringEpisodeCount,
+	SleepSummaryFieldTotalSleepTime, SleepSummaryFieldTotalTimeInBed, SleepSummaryFieldWakeupCount,
+	SleepSummaryFieldWakeupDuration, SleepSummaryFieldWakeupLatency, SleepSummaryFieldWASO,
+}
+
+// SleepSummaryParam is the parameter needed to specify what sleep summaries to retrieve. Either StartDate and
+// EndDate or LastUpdate must be provided. Only the calendar day of StartDate and EndDate is used.
+type SleepSummaryParam struct {
+	StartDate  time.Time
+	EndDate    time.Time
+	LastUpdate time.Time
+	// Fields selects the data fields to return. All fields are returned if none are provided.
+	Fields []SleepSummaryField
+	Offset int
+}
+
+// URLEncode encodes the parameter into the form values of a get sleep summary request.
+func (p SleepSummaryParam) URLEncode() (string, error) {
+	v := url.Values{}
+	v.Add("action", "getsummary")
+
+	switch {
+	case !p.LastUpdate.IsZero():
+		v.Add("lastupdate", strconv.FormatInt(p.LastUpdate.Unix(), 10))
+	case !p.StartDate.IsZero() && !p.EndDate.IsZero():
+		v.Add("startdateymd", p.StartDate.Format(time.DateOnly))
+		v.Add("enddateymd", p.EndDate.Format(time.DateOnly))
+	default:
+		return "", errors.New("either a start and end date or a last update must be provided")
+	}
+
+	fields := p.Fields
+	if len(fields) == 0 {
+		fields = AllSleepSummaryFields
+	}
+	v.Add("data_fields", joinFields(fields))
+
+	if p.Offset > 0 {
+		v.Add("offset", strconv.Itoa(p.Offset))
+	}
+
+	return v.Encode(), nil
+}
+
+// SleepSummaryResponse is the raw response of a get sleep summary API request.
+type SleepSummaryResponse struct {
+	Series []SleepSummary `json:"series"`
+	More   bool           `json:"more"`
+	Offset int            `json:"offset"`
+}
+
+// nextOffset implements pagedResponse.
+func (r SleepSummaryResponse) nextOffset() (int, bool) {
+	return r.Offset, r.More && r.Offset != 0
+}
+
+// SleepSummary is the summary of a single night of sleep.
+type SleepSummary struct {
+	ID           int64            `json:"id"`
+	Timezone     string           `json:"timezone"`
+	Model        int64            `json:"model"`
+	ModelID      int64            `json:"model_id"`
+	HashDeviceID string           `json:"hash_deviceid"`
+	StartDate    int64            `json:"startdate"`
+	EndDate      int64            `json:"enddate"`
+	Date         string           `json:"date"`
+	Created      int64            `json:"created"`
+	Modified     int64            `json:"modified"`
+	Data         SleepSummaryData `json:"data"`
+}
+
+// SleepSummaryData are the values of a sleep summary. Durations are in seconds, heart rates in beats per minute and
+// respiration rates in breaths per minute.
+type SleepSummaryData struct {
+	BreathingDisturbancesIntensity int64   `json:"breathing_disturbances_intensity"`
+	DeepSleepDuration              int64   `json:"deepsleepduration"`
+	DurationToSleep                int64   `json:"durationtosleep"`
+	DurationToWakeup               int64   `json:"durationtowakeup"`
+	HRAverage                      int64   `json:"hr_average"`
+	HRMax                          int64   `json:"hr_max"`
+	HRMin                          int64   `json:"hr_min"`
+	LightSleepDuration             int64   `json:"lightsleepduration"`
+	NightEvents                    []int64 `json:"night_events"`
+	OutOfBedCount                  int64   `json:"out_of_bed_count"`
+	REMSleepDuration               int64   `json:"remsleepduration"`
+	RRAverage                      int64   `json:"rr_average"`
+	RRMax                          int64   `json:"rr_max"`
+	RRMin                          int64   `json:"rr_min"`
+	SleepEfficiency                float64 `json:"sleep_efficiency"`
+	SleepLatency                   int64   `json:"sleep_latency"`
+	SleepScore                     int64   `json:"sleep_score"`
+	Snoring                        int64   `json:"snoring"`
+	SnoringEpisodeCount            int64   `json:"snoringepisodecount"`
+	TotalSleepTime                 int64   `json:"total_sleep_time"`
+	TotalTimeInBed                 int64   `json:"total_timeinbed"`
+	WakeupCount                    int64   `json:"wakeupcount"`
+	WakeupDuration                 int64   `json:"wakeupduration"`
+	WakeupLatency                  int64   `json:"wakeup_latency"`
+	WASO                           int64   `json:"waso"`
+}
+
+// seconds converts a number of seconds into a duration.
+func seconds(s int64) time.Duration {
+	return time.Duration(s) * time.Second
+}
+
+// Location returns the timezone the night was recorded in, falling back to UTC.
+func (s SleepSummary) Location() *time.Location {
+	return resolveLocation(s.Timezone)
+}
+
+// Start returns the time the user went to bed in the timezone the night was recorded in.
+func (s SleepSummary) Start() time.Time {
+	return time.Unix(s.StartDate, 0).In(s.Location())
+}
+
+// End returns the time the user got out of bed in the timezone the night was recorded in.
+func (s SleepSummary) End() time.Time {
+	return time.Unix(s.EndDate, 0).In(s.Location())
+}
+
+// TotalSleep returns the total time spent asleep.
+func (s SleepSummary) TotalSleep() time.Duration {
+	return seconds(s.Data.TotalSleepTime)
+}
+
+// LightSleep returns the time spent in light sleep.
+func (s SleepSummary) LightSleep() time.Duration {
+	return seconds(s.Data.LightSleepDuration)
+}
+
+// DeepSleep returns the time spent in deep sleep.
+func (s SleepSummary) DeepSleep() time.Duration {
+	return seconds(s.Data.DeepSleepDuration)
+}
+
+// REMSleep returns the time spent in REM sleep.
+func (s SleepSummary) REMSleep() time.Duration {
+	return seconds(s.Data.REMSleepDuration)
+}
+
+// WakeupDuration returns the time spent awake after falling asleep.
+func (s SleepSummary) WakeupDuration() time.Duration {
+	return seconds(s.Data.WakeupDuration)
+}
+
+// SleepLatency returns the time it took the user to fall asleep.
+func (s SleepSummary) SleepLatency() time.Duration {
+	return seconds(s.Data.SleepLatency)
+}
+
+// Snoring returns the time spent snoring.
+func (s SleepSummary) Snoring() time.Duration {
+	return seconds(s.Data.Snoring)
+}
+
+// GetSleepSummaries returns the sleep summaries as specified by the request param, iterating over the offset until
+// every summary is retrieved.
+func (c *UserClient) GetSleepSummaries(ctx context.Context, param SleepSummaryParam) ([]SleepSummary, error) {
+	return getAllPages(ctx, c, SleepV2URL, "get sleep summaries", param.Offset, func(offset int) (string, error) {
+		param.Offset = offset
+		return param.URLEncode()
+	}, func(resp SleepSummaryResponse) []SleepSummary {
+		return resp.Series
+	})
+}
@@ -0,0 +1,69 @@
+package gowithings_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/canadyworkshop/gowithings"
+)
+
+func TestSleepSummaryParam_URLEncode(t *testing.T) {
+	night := time.Date(2024, 3, 10, 23, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		param   gowithings.SleepSummaryParam
+		want    url.Values
+		wantErr bool
+	}{
+		{
+			name:  "dates",
+			param: gowithings.SleepSummaryParam{StartDate: night, EndDate: night.AddDate(0, 0, 7), Fields: []gowithings.SleepSummaryField{gowithings.SleepSummaryFieldSleepScore}},
+			want:  url.Values{"action": {"getsummary"}, "startdateymd": {"2024-03-10"}, "enddateymd": {"2024-03-17"}, "data_fields": {"sleep_score"}},
+		},
+		{
+			name:  "last update",
+			param: gowithings.SleepSummaryParam{LastUpdate: time.Unix(1700000000, 0), Offset: 10, Fields: []gowithings.SleepSummaryField{gowithings.SleepSummaryFieldSleepScore, gowithings.SleepSummaryFieldWASO}},
+			want:  url.Values{"action": {"getsummary"}, "lastupdate": {"1700000000"}, "offset": {"10"}, "data_fields": {"sleep_score,waso"}},
+		},
+		{
+			name:    "no range",
+			param:   gowithings.SleepSummaryParam{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := tt.param.URLEncode()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got, want := encoded, tt.want.Encode(); got != want {
+				t.Errorf("got %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestUserClient_GetSleepSummaries(t *testing.T) {
+	// A page reporting more results without an offset ends the iteration instead of requesting the first page again.
+	api := pagedAPI(map[string]string{
+		"0": `{"series":[{"id":1}],"more":true,"offset":5}`,
+		"5": `{"series":[{"id":2}],"more":true,"offset":0}`,
+	})
+	uc := newTestUserClient(t, api)
+
+	summaries, err := uc.GetSleepSummaries(context.Background(), gowithings.SleepSummaryParam{LastUpdate: time.Unix(1700000000, 0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summaries) != 2 || summaries[0].ID != 1 || summaries[1].ID != 2 {
+		t.Errorf("summaries = %+v, want both pages in order", summaries)
+	}
+	checkOffsets(t, api, "", "5")
+}
@@ -0,0 +1,80 @@
+package gowithings_test
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/canadyworkshop/gowithings"
+)
+
+func TestDecodeTimeSeries(t *testing.T) {
+	values, err := gowithings.DecodeTimeSeries(map[string]float64{"300": 58, "100": 62, "200": 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		ts    int64
+		value float64
+	}{{100, 62}, {200, 60}, {300, 58}}
+	if len(values) != len(want) {
+		t.Fatalf("got %d values, want %d", len(values), len(want))
+	}
+	for i, w := range want {
+		if values[i].Time.Unix() != w.ts || values[i].Value != w.value {
+			t.Errorf("value %d = %v/%v, want %d/%v", i, values[i].Time.Unix(), values[i].Value, w.ts, w.value)
+		}
+	}
+
+	if values, err := gowithings.DecodeTimeSeries(nil); err != nil || values != nil {
+		t.Errorf("empty series = %v, %v, want nil, nil", values, err)
+	}
+	if _, err := gowithings.DecodeTimeSeries(map[string]float64{"later": 1}); err == nil {
+		t.Error("expected an error for an invalid timestamp")
+	}
+}
+
+func TestUserClient_GetSleep(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	end := start.Add(30 * time.Hour)
+	at := func(offset int64) int64 { return start.Unix() + offset }
+
+	// The segment spanning the window boundary is returned by both responses.
+	api := &fakeAPI{respond: func(form url.Values) string {
+		if form.Get("startdate") == strconv.FormatInt(start.Unix(), 10) {
+			return fmt.Sprintf(`{"series":[{"startdate":%d,"enddate":%d,"state":2,"hash_deviceid":"a","hr":{"%d":55}},
+				{"startdate":%d,"enddate":%d,"state":1,"hash_deviceid":"a"}]}`, at(2000), at(2600), at(2000), at(1000), at(2000))
+		}
+		return fmt.Sprintf(`{"series":[{"startdate":%d,"enddate":%d,"state":2,"hash_deviceid":"a","hr":{"%d":55}},
+			{"startdate":%d,"enddate":%d,"state":0,"hash_deviceid":"a"}]}`, at(2000), at(2600), at(2000), at(2600), at(3000))
+	}}
+	uc := newTestUserClient(t, api)
+
+	segments, err := uc.GetSleep(context.Background(), start, end, []gowithings.SleepField{gowithings.SleepFieldHR})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requests := api.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("got %d requests, want one per window", len(requests))
+	}
+	if got := requests[0].Get("data_fields"); got != "hr" {
+		t.Errorf("data_fields = %s, want hr", got)
+	}
+
+	if len(segments) != 3 {
+		t.Fatalf("got %d segments, want 3", len(segments))
+	}
+	for i, offset := range []int64{1000, 2000, 2600} {
+		if segments[i].Start.Unix() != at(offset) {
+			t.Errorf("segment %d starts at %d, want %d", i, segments[i].Start.Unix(), at(offset))
+		}
+	}
+	if s := segments[1]; s.State != gowithings.SleepStateDeep || len(s.HR) != 1 || s.RR != nil {
+		t.Errorf("segment 1 = %+v, want deep sleep with one heart rate value", s)
+	}
+}
@@ -0,0 +1,209 @@
+package gowithings
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// VHDResult is the classification of a stethoscope recording for valvular heart disease.
+type VHDResult int64
+
+const (
+	VHDNegative     VHDResult = 0
+	VHDPositive     VHDResult = 1
+	VHDInconclusive VHDResult = 2
+)
+
+// String returns the name of the result or its numeric value if it is unknown.
+func (r VHDResult) String() string {
+	switch r {
+	case VHDNegative:
+		return "Negative"
+	case VHDPositive:
+		return "Positive"
+	case VHDInconclusive:
+		return "Inconclusive"
+	}
+	return fmt.Sprintf("VHDResult(%d)", int64(r))
+}
+
+// StethoListParam is the parameter needed to specify what stethoscope recordings to list. Without dates every
+// recording is listed.
+type StethoListParam struct {
+	StartDate time.Time
+	EndDate   time.Time
+	Offset    int
+}
+
+// URLEncode encodes the parameter into the form values of a list stethoscope recording request.
+func (p StethoListParam) URLEncode() (string, error) {
+	v := url.Values{}
+	v.Add("action", "list")
+
+	if !p.StartDate.IsZero() {
+		v.Add("startdate", strconv.FormatInt(p.StartDate.Unix(), 10))
+	}
+
+	if !p.EndDate.IsZero() {
+		v.Add("enddate", strconv.FormatInt(p.EndDate.Unix(), 10))
+	}
+
+	if p.Offset > 0 {
+		v.Add("offset", strconv.Itoa(p.Offset))
+	}
+
+	return v.Encode(), nil
+}
+
+// StethoListResponse is the raw response of a list stethoscope recordings API request.
+type StethoListResponse struct {
+	Series []StethoRecording `json:"series"`
+	More   bool              `json:"more"`
+	Offset int               `json:"offset"`
+}
+
+// nextOffset implements pagedResponse.
+func (r StethoListResponse) nextOffset() (int, bool) {
+	return r.Offset, r.More && r.Offset != 0
+}
+
+// StethoRecording is a stethoscope recording as listed by the API.
+type StethoRecording struct {
+	DeviceID     string    `json:"deviceid"`
+	HashDeviceID string    `json:"hash_deviceid"`
+	Model        int64     `json:"model"`
+	SignalID     int64     `json:"signalid"`
+	Timestamp    int64     `json:"timestamp"`
+	VHD          VHDResult `json:"vhd"`
+	Timezone     string    `json:"timezone"`
+}
+
+// Location returns the timezone the recording was made in, falling back to UTC.
+func (r StethoRecording) Location() *time.Location {
+	return resolveLocation(r.Timezone)
+}
+
+// RecordedAt returns the time of the recording in the timezone it was made in.
+func (r StethoRecording) RecordedAt() time.Time {
+	return time.Unix(r.Timestamp, 0).In(r.Location())
+}
+
+// StethoSignal is the raw audio of a stethoscope recording.
+type StethoSignal struct {
+	SignalID int64 `json:"-"`
+	// Samples is the audio signal.
+	Samples []int32 `json:"signal"`
+	// Frequency is the number of samples per second.
+	Frequency int `json:"frequency"`
+	// Resolution is the number of significant bits per sample.
+	Resolution int       `json:"resolution"`
+	Channel    int       `json:"channel"`
+	Model      int64     `json:"model"`
+	VHD        VHDResult `json:"vhd"`
+	Position   int64     `json:"stethoscope_position"`
+}
+
+// Duration returns the length of the recording.
+func (s StethoSignal) Duration() time.Duration {
+	if s.Frequency <= 0 {
+		return 0
+	}
+	return time.Duration(len(s.Samples)) * time.Second / time.Duration(s.Frequency)
+}
+
+// pcm16 converts the samples to 16 bit PCM, scaling samples with a higher resolution down and clamping samples that
+// would still overflow.
+func (s StethoSignal) pcm16() []int16 {
+	shift := 0
+	if s.Resolution > 16 {
+		shift = s.Resolution - 16
+	}
+
+	pcm := make([]int16, len(s.Samples))
+	for i, v := range s.Samples {
+		v >>= shift
+		switch {
+		case v > math.MaxInt16:
+			v = math.MaxInt16
+		case v < math.MinInt16:
+			v = math.MinInt16
+		}
+		pcm[i] = int16(v)
+	}
+	return pcm
+}
+
+// WriteWAV writes the signal as a mono 16 bit PCM WAV file.
+func (s StethoSignal) WriteWAV(w io.Writer) error {
+	if s.Frequency <= 0 {
+		return errors.New("signal has no sampling frequency")
+	}
+
+	const (
+		channels      = 1
+		bitsPerSample = 16
+		blockAlign    = channels * bitsPerSample / 8
+	)
+	pcm := s.pcm16()
+	dataSize := uint32(len(pcm) * blockAlign)
+
+	header := []interface{}{
+		[4]byte{'R', 'I', 'F', 'F'},
+		36 + dataSize,
+		[4]byte{'W', 'A', 'V', 'E'},
+		[4]byte{'f', 'm', 't', ' '},
+		uint32(16),
+		uint16(1), // PCM
+		uint16(channels),
+		uint32(s.Frequency),
+		uint32(s.Frequency * blockAlign),
+		uint16(blockAlign),
+		uint16(bitsPerSample),
+		[4]byte{'d', 'a', 't', 'a'},
+		dataSize,
+	}
+	for _, v := range header {
+		if err := binary.Write(w, binary.LittleEndian, v); err != nil {
+			return fmt.Errorf("failed to write WAV header: %w", err)
+		}
+	}
+
+	if err := binary.Write(w, binary.LittleEndian, pcm); err != nil {
+		return fmt.Errorf("failed to write WAV data: %w", err)
+	}
+	return nil
+}
+
+// ListStethoRecordings returns the stethoscope recordings as specified by the request param, iterating over the
+// offset until every recording is retrieved.
+func (c *UserClient) ListStethoRecordings(ctx context.Context, param StethoListParam) ([]StethoRecording, error) {
+	return getAllPages(ctx, c, StethoV2URL, "list stetho recordings", param.Offset, func(offset int) (string, error) {
+		param.Offset = offset
+		return param.URLEncode()
+	}, func(resp StethoListResponse) []StethoRecording {
+		return resp.Series
+	})
+}
+
+// GetStethoSignal returns the raw audio of the stethoscope recording identified by the signal ID, as found in
+// StethoRecording.SignalID.
+func (c *UserClient) GetStethoSignal(ctx context.Context, signalID int64) (StethoSignal, error) {
+	v := url.Values{}
+	v.Add("action", "get")
+	v.Add("signalid", strconv.FormatInt(signalID, 10))
+
+	signal := StethoSignal{}
+	if _, err := c.post(ctx, StethoV2URL, v.Encode(), &signal); err != nil {
+		return signal, fmt.Errorf("failed to get stetho signal %d: %w", signalID, err)
+	}
+	signal.SignalID = signalID
+
+	return signal, nil
+}
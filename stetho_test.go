@@ -0,0 +1,59 @@
+package gowithings_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/canadyworkshop/gowithings"
+)
+
+func TestStethoSignal_WriteWAV(t *testing.T) {
+	s := gowithings.StethoSignal{
+		Samples:    []int32{0, 1 << 8, -(1 << 8), 1 << 20},
+		Frequency:  4000,
+		Resolution: 24,
+	}
+
+	buf := &bytes.Buffer{}
+	if err := s.WriteWAV(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	b := buf.Bytes()
+	if len(b) != 44+len(s.Samples)*2 {
+		t.Fatalf("len = %d, want %d", len(b), 44+len(s.Samples)*2)
+	}
+	if string(b[0:4]) != "RIFF" || string(b[8:12]) != "WAVE" || string(b[36:40]) != "data" {
+		t.Errorf("invalid WAV header %q", b[:44])
+	}
+	if rate := binary.LittleEndian.Uint32(b[24:28]); rate != 4000 {
+		t.Errorf("sample rate = %d, want 4000", rate)
+	}
+
+	want := []int16{0, 1, -1, 1 << 12}
+	for i, w := range want {
+		if got := int16(binary.LittleEndian.Uint16(b[44+i*2:])); got != w {
+			t.Errorf("sample %d = %d, want %d", i, got, w)
+		}
+	}
+}
+
+func TestUserClient_ListStethoRecordings(t *testing.T) {
+	api := pagedAPI(map[string]string{
+		"3": `{"series":[{"signalid":4}],"more":true,"offset":4}`,
+		"4": `{"series":[{"signalid":5}],"more":false,"offset":0}`,
+	})
+	uc := newTestUserClient(t, api)
+
+	// The iteration starts from the offset of the parameter.
+	recordings, err := uc.ListStethoRecordings(context.Background(), gowithings.StethoListParam{Offset: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recordings) != 2 || recordings[0].SignalID != 4 || recordings[1].SignalID != 5 {
+		t.Errorf("recordings = %+v, want both pages in order", recordings)
+	}
+	checkOffsets(t, api, "3", "4")
+}
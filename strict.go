@@ -0,0 +1,170 @@
+package gowithings
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// DecodeWarningKind is the kind of API drift reported by a DecodeWarning.
+type DecodeWarningKind int
+
+const (
+	// WarningUnknownField is a JSON field in the response that the library does not decode.
+	WarningUnknownField DecodeWarningKind = iota + 1
+	// WarningUnknownMeasureType is a measure type code missing from MeasureTypesByKey.
+	WarningUnknownMeasureType
+	// WarningUnknownAttrib is an attrib value the library does not know.
+	WarningUnknownAttrib
+)
+
+// String returns the name of the warning kind.
+func (k DecodeWarningKind) String() string {
+	switch k {
+	case WarningUnknownField:
+		return "UnknownField"
+	case WarningUnknownMeasureType:
+		return "UnknownMeasureType"
+	case WarningUnknownAttrib:
+		return "UnknownAttrib"
+	}
+	return fmt.Sprintf("DecodeWarningKind(%d)", int(k))
+}
+
+// DecodeWarning reports part of an API response that the library could not fully understand. Warnings are only
+// produced in strict mode.
+type DecodeWarning struct {
+	Kind DecodeWarningKind
+	// Path is the JSON path of the unknown value, such as body.measuregrps[3].measures[0].type.
+	Path string
+	// GroupID is the ID of the measure group the warning relates to, if any.
+	GroupID int64
+	// Value is the unknown code for WarningUnknownMeasureType and WarningUnknownAttrib.
+	Value int64
+}
+
+// String returns a human readable description of the warning.
+func (w DecodeWarning) String() string {
+	switch w.Kind {
+	case WarningUnknownField:
+		return fmt.Sprintf("unknown field %s", w.Path)
+	case WarningUnknownMeasureType:
+		return fmt.Sprintf("unknown measure type %d at %s", w.Value, w.Path)
+	case WarningUnknownAttrib:
+		return fmt.Sprintf("unknown attrib %d at %s", w.Value, w.Path)
+	}
+	return fmt.Sprintf("%s at %s", w.Kind, w.Path)
+}
+
+// unknownFields returns the paths of the JSON object keys in data that would be dropped when decoding into a value
+// of type t. Nested structs and slices are checked recursively, maps and values that fail to decode are not.
+func unknownFields(data []byte, t reflect.Type, path string) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object := map[string]json.RawMessage{}
+		if err := json.Unmarshal(data, &object); err != nil {
+			return nil
+		}
+
+		fields := make(map[string]reflect.Type)
+		collectJSONFields(t, fields)
+
+		unknown := make([]string, 0)
+		for key, value := range object {
+			fieldPath := key
+			if path != "" {
+				fieldPath = path + "." + key
+			}
+			fieldType, ok := fields[strings.ToLower(key)]
+			if !ok {
+				unknown = append(unknown, fieldPath)
+				continue
+			}
+			unknown = append(unknown, unknownFields(value, fieldType, fieldPath)...)
+		}
+		sort.Strings(unknown)
+		return unknown
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return nil
+		}
+		items := make([]json.RawMessage, 0)
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil
+		}
+		unknown := make([]string, 0)
+		for i, item := range items {
+			unknown = append(unknown, unknownFields(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return unknown
+	}
+	return nil
+}
+
+// collectJSONFields adds the lower cased JSON names of the fields of the struct type t to fields, following the
+// naming rules of encoding/json including embedded structs.
+func collectJSONFields(t reflect.Type, fields map[string]reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				collectJSONFields(embedded, fields)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = field.Type
+	}
+}
+
+// measureWarnings returns the strict mode warnings of a raw get measure response and the decoded groups.
+func measureWarnings(body []byte, groups []MeasureGroup) []DecodeWarning {
+	warnings := make([]DecodeWarning, 0)
+	for _, path := range unknownFields(body, reflect.TypeOf(GetMeasureResponseWrapper{}), "") {
+		warnings = append(warnings, DecodeWarning{Kind: WarningUnknownField, Path: path})
+	}
+
+	for i, mg := range groups {
+		if !mg.Attrib.IsKnown() {
+			warnings = append(warnings, DecodeWarning{
+				Kind:    WarningUnknownAttrib,
+				Path:    fmt.Sprintf("body.measuregrps[%d].attrib", i),
+				GroupID: mg.GroupID,
+				Value:   int64(mg.Attrib),
+			})
+		}
+		for j, m := range mg.Measures {
+			if _, ok := MeasureTypesByKey[int(m.Type)]; !ok {
+				warnings = append(warnings, DecodeWarning{
+					Kind:    WarningUnknownMeasureType,
+					Path:    fmt.Sprintf("body.measuregrps[%d].measures[%d].type", i, j),
+					GroupID: mg.GroupID,
+					Value:   m.Type,
+				})
+			}
+		}
+	}
+
+	return warnings
+}
@@ -0,0 +1,62 @@
+package gowithings_test
+
+import (
+	"testing"
+
+	"github.com/canadyworkshop/gowithings"
+)
+
+const driftResponse = `{
+	"status": 0,
+	"body": {
+		"updatetime": 1700000000,
+		"timezone": "Europe/Paris",
+		"measuregrps": [{
+			"grpid": 42,
+			"attrib": 99,
+			"date": 1700000000,
+			"category": 1,
+			"new_group_field": true,
+			"measures": [
+				{"value": 72000, "type": 1, "unit": -3},
+				{"value": 12, "type": 999, "unit": 0, "new_measure_field": 1}
+			]
+		}]
+	}
+}`
+
+func TestParseMeasureResponse_Strict(t *testing.T) {
+	r, err := gowithings.ParseMeasureResponse([]byte(driftResponse), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []gowithings.DecodeWarning{
+		{Kind: gowithings.WarningUnknownField, Path: "body.measuregrps[0].measures[1].new_measure_field"},
+		{Kind: gowithings.WarningUnknownField, Path: "body.measuregrps[0].new_group_field"},
+		{Kind: gowithings.WarningUnknownAttrib, Path: "body.measuregrps[0].attrib", GroupID: 42, Value: 99},
+		{Kind: gowithings.WarningUnknownMeasureType, Path: "body.measuregrps[0].measures[1].type", GroupID: 42, Value: 999},
+	}
+	if len(r.Warnings) != len(want) {
+		t.Fatalf("got %d warnings %v, want %d", len(r.Warnings), r.Warnings, len(want))
+	}
+	for i := range want {
+		if r.Warnings[i] != want[i] {
+			t.Errorf("Warnings[%d] = %v, want %v", i, r.Warnings[i], want[i])
+		}
+	}
+
+	if len(r.MeasureGroups[0].RawJSON) == 0 {
+		t.Error("RawJSON was not kept")
+	}
+}
+
+func TestParseMeasureResponse_NotStrict(t *testing.T) {
+	r, err := gowithings.ParseMeasureResponse([]byte(driftResponse), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Warnings) != 0 {
+		t.Errorf("got %d warnings outside strict mode", len(r.Warnings))
+	}
+}
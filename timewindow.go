@@ -0,0 +1,23 @@
+package gowithings
+
+import "time"
+
+// timeWindow is a time range of a request split to respect the limits of the API.
+type timeWindow struct {
+	start time.Time
+	end   time.Time
+}
+
+// splitTimeRange splits the range between start and end into consecutive windows no longer than size. The last
+// window always ends at end.
+func splitTimeRange(start, end time.Time, size time.Duration) []timeWindow {
+	windows := make([]timeWindow, 0)
+	for s := start; s.Before(end); s = s.Add(size) {
+		e := s.Add(size - time.Second)
+		if !s.Add(size).Before(end) {
+			e = end
+		}
+		windows = append(windows, timeWindow{start: s, end: e})
+	}
+	return windows
+}
@@ -0,0 +1,233 @@
+package gowithings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Gender is the gender of a user.
+type Gender int64
+
+const (
+	GenderMale   Gender = 0
+	GenderFemale Gender = 1
+)
+
+// String returns the name of the gender or its numeric value if it is unknown.
+func (g Gender) String() string {
+	switch g {
+	case GenderMale:
+		return "Male"
+	case GenderFemale:
+		return "Female"
+	}
+	return fmt.Sprintf("Gender(%d)", int64(g))
+}
+
+// Unit is a unit the user prefers values to be displayed in.
+type Unit int64
+
+const (
+	UnitKilogram   Unit = 1
+	UnitPound      Unit = 2
+	UnitMeter      Unit = 6
+	UnitImperial   Unit = 7
+	UnitMile       Unit = 8
+	UnitCelsius    Unit = 11
+	UnitFahrenheit Unit = 13
+)
+
+// UnitPreferences are the units the user prefers values to be displayed in.
+type UnitPreferences struct {
+	Weight      Unit `json:"weight"`
+	Height      Unit `json:"height"`
+	Distance    Unit `json:"distance"`
+	Temperature Unit `json:"temperature"`
+}
+
+// MetricUnits are the preferences of users of the metric system.
+var MetricUnits = UnitPreferences{Weight: UnitKilogram, Height: UnitMeter, Distance: UnitMeter, Temperature: UnitCelsius}
+
+// ImperialUnits are the preferences of users of the imperial system.
+var ImperialUnits = UnitPreferences{Weight: UnitPound, Height: UnitImperial, Distance: UnitMile, Temperature: UnitFahrenheit}
+
+// ActivateUserParam is the parameter needed to create a user as a partner. Birthdate, Email, ShortName, Height and
+// Weight are required.
+type ActivateUserParam struct {
+	Birthdate time.Time
+	Gender    Gender
+	// Height is in meters and Weight in kilograms.
+	Height float64
+	Weight float64
+	// Units defaults to MetricUnits.
+	Units *UnitPreferences
+	// Timezone is the IANA name of the timezone of the user. Defaults to UTC.
+	Timezone string
+	// Email identifies the user. It is used to recover the authorization code of the user.
+	Email string
+	// ShortName is the three letter name displayed on the devices of the user.
+	ShortName string
+	// ExternalID is the identifier of the user in the partner system.
+	ExternalID string
+	// Language is the preferred language of the user, such as en_EN. Defaults to en_EN.
+	Language string
+	// MACAddresses are the devices linked to the user once created.
+	MACAddresses []string
+}
+
+// activationMeasure is a measure of the user provided on activation.
+type activationMeasure struct {
+	Value int64 `json:"value"`
+	Unit  int64 `json:"unit"`
+	Type  int64 `json:"type"`
+}
+
+// values encodes the parameter values. The redirect URL is the one the authorization code is issued for.
+func (p ActivateUserParam) values(redirectURL string) (url.Values, error) {
+	switch {
+	case p.Birthdate.IsZero():
+		return nil, errors.New("no birthdate provided")
+	case p.Email == "":
+		return nil, errors.New("no email provided")
+	case len(p.ShortName) != 3:
+		return nil, fmt.Errorf("short name %q must be three letters", p.ShortName)
+	case p.Height <= 0 || p.Weight <= 0:
+		return nil, errors.New("height and weight must be provided")
+	}
+
+	// Values are sent with two decimals as the API expects integers with a power of ten unit.
+	measures, err := json.Marshal([]activationMeasure{
+		{Value: int64(math.Round(p.Height * 100)), Unit: -2, Type: 4},
+		{Value: int64(math.Round(p.Weight * 100)), Unit: -2, Type: 1},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode measures: %w", err)
+	}
+
+	units := MetricUnits
+	if p.Units != nil {
+		units = *p.Units
+	}
+	unitPref, err := json.Marshal(units)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode unit preferences: %w", err)
+	}
+
+	timezone := p.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	if loadLocation(timezone) == nil {
+		return nil, fmt.Errorf("unknown timezone %q", timezone)
+	}
+
+	language := p.Language
+	if language == "" {
+		language = "en_EN"
+	}
+
+	v := url.Values{}
+	v.Add("redirect_uri", redirectURL)
+	v.Add("birthdate", strconv.FormatInt(p.Birthdate.Unix(), 10))
+	v.Add("gender", strconv.FormatInt(int64(p.Gender), 10))
+	v.Add("measures", string(measures))
+	v.Add("unit_pref", string(unitPref))
+	v.Add("timezone", timezone)
+	v.Add("email", p.Email)
+	v.Add("shortname", p.ShortName)
+	v.Add("preflang", language)
+	if p.ExternalID != "" {
+		v.Add("external_id", p.ExternalID)
+	}
+	if len(p.MACAddresses) > 0 {
+		macAddresses, err := json.Marshal(p.MACAddresses)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode MAC addresses: %w", err)
+		}
+		v.Add("mac_addresses", string(macAddresses))
+	}
+
+	return v, nil
+}
+
+// ActivatedUser is a user created by a partner.
+type ActivatedUser struct {
+	// Code is the authorization code to exchange for the token of the user with NewUserClientFromCode.
+	Code       string `json:"code"`
+	ExternalID string `json:"external_id"`
+}
+
+// activateUserResponse is the raw response of an activate or recover API request.
+type activateUserResponse struct {
+	User ActivatedUser `json:"user"`
+}
+
+// ActivateUser creates a user and links the devices provided to it. The authorization code returned is issued for
+// the redirect URL of the client.
+func (client *Client) ActivateUser(ctx context.Context, param ActivateUserParam) (ActivatedUser, error) {
+	v, err := param.values(client.config.RedirectURL)
+	if err != nil {
+		return ActivatedUser{}, fmt.Errorf("failed to generate values: %w", err)
+	}
+
+	resp := activateUserResponse{}
+	if err := client.SignedRequest(ctx, UserV2URL, "activate", v, &resp); err != nil {
+		return ActivatedUser{}, fmt.Errorf("failed to activate user: %w", err)
+	}
+	return resp.User, nil
+}
+
+// RecoverAuthorizationCode returns a new authorization code for a user created with ActivateUser, identified by the
+// email it was created with.
+func (client *Client) RecoverAuthorizationCode(ctx context.Context, email string) (string, error) {
+	if email == "" {
+		return "", errors.New("no email provided")
+	}
+
+	v := url.Values{}
+	v.Add("email", email)
+
+	resp := activateUserResponse{}
+	if err := client.SignedRequest(ctx, RequestTokenURL, "recoverauthorizationcode", v, &resp); err != nil {
+		return "", fmt.Errorf("failed to recover authorization code: %w", err)
+	}
+	return resp.User.Code, nil
+}
+
+// LinkDevice links the device with the MAC address provided to the user.
+func (c *UserClient) LinkDevice(ctx context.Context, macAddress string) error {
+	if macAddress == "" {
+		return errors.New("no MAC address provided")
+	}
+
+	v := url.Values{}
+	v.Add("action", "link")
+	v.Add("mac_address", macAddress)
+
+	if _, err := c.post(ctx, UserV2URL, v.Encode(), nil); err != nil {
+		return fmt.Errorf("failed to link device %s: %w", macAddress, err)
+	}
+	return nil
+}
+
+// UnlinkDevice unlinks the device with the MAC address provided from the user.
+func (c *UserClient) UnlinkDevice(ctx context.Context, macAddress string) error {
+	if macAddress == "" {
+		return errors.New("no MAC address provided")
+	}
+
+	v := url.Values{}
+	v.Add("action", "unlink")
+	v.Add("mac_address", macAddress)
+
+	if _, err := c.post(ctx, UserV2URL, v.Encode(), nil); err != nil {
+		return fmt.Errorf("failed to unlink device %s: %w", macAddress, err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package gowithings_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/canadyworkshop/gowithings"
+)
+
+func TestClient_ActivateUser_Invalid(t *testing.T) {
+	client := gowithings.NewClient(gowithings.Config{})
+	valid := gowithings.ActivateUserParam{
+		Birthdate: time.Date(1980, 1, 2, 0, 0, 0, 0, time.UTC),
+		Height:    1.8,
+		Weight:    75.5,
+		Email:     "patient@example.com",
+		ShortName: "PAT",
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*gowithings.ActivateUserParam)
+	}{
+		{"no birthdate", func(p *gowithings.ActivateUserParam) { p.Birthdate = time.Time{} }},
+		{"no email", func(p *gowithings.ActivateUserParam) { p.Email = "" }},
+		{"long short name", func(p *gowithings.ActivateUserParam) { p.ShortName = "PATIENT" }},
+		{"no weight", func(p *gowithings.ActivateUserParam) { p.Weight = 0 }},
+		{"unknown timezone", func(p *gowithings.ActivateUserParam) { p.Timezone = "Mars/Olympus_Mons" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			param := valid
+			tt.modify(&param)
+			if _, err := client.ActivateUser(context.Background(), param); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
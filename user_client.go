@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 )
@@ -17,12 +18,17 @@ type UserClient struct {
 	clientSecret string
 	token        RequestToken
 	httpClient   *http.Client
+	limiter      *rateLimiter
 	sync.Mutex
 }
 
 // newRequest will create a new http request with a valid auth header. If the access token is
-// expired it will automatically refresh it.
+// expired it will automatically refresh it. The request is only created once the rate limit of the client allows it.
 func (c *UserClient) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	if err := c.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+
 	c.Lock()
 	defer c.Unlock()
 
@@ -51,6 +57,87 @@ func (c *UserClient) newRequest(ctx context.Context, method, path string, body i
 	return req, nil
 }
 
+// apiResponseWrapper is the envelope shared by the responses of the API.
+type apiResponseWrapper struct {
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body"`
+	Error  string          `json:"error"`
+}
+
+// post performs a form encoded POST of the values provided to the API URL and decodes the body of the response into
+// out. The raw response is returned so callers can inspect it further.
+func (c *UserClient) post(ctx context.Context, apiURL, values string, out interface{}) ([]byte, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, apiURL, strings.NewReader(values))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return body, decodeAPIResponse(body, out)
+}
+
+// pagedResponse is the response of a request whose results are returned over several pages.
+type pagedResponse interface {
+	// nextOffset returns the offset of the next page and whether there is one.
+	nextOffset() (int, bool)
+}
+
+// getAllPages performs the request encoded for each offset, starting from the offset provided, until the API reports
+// there are no more pages and returns the items of every page. Action describes the request in errors.
+func getAllPages[R pagedResponse, T any](ctx context.Context, c *UserClient, apiURL, action string, offset int,
+	encode func(offset int) (string, error), items func(R) []T) ([]T, error) {
+	all := make([]T, 0)
+	for {
+		values, err := encode(offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate values: %w", err)
+		}
+
+		var resp R
+		if _, err := c.post(ctx, apiURL, values, &resp); err != nil {
+			return nil, fmt.Errorf("failed to %s at offset %d: %w", action, offset, err)
+		}
+		all = append(all, items(resp)...)
+
+		next, more := resp.nextOffset()
+		if !more {
+			break
+		}
+		offset = next
+	}
+	return all, nil
+}
+
+// decodeAPIResponse decodes the body of the API response into out, returning an APIError if its status is not zero.
+func decodeAPIResponse(body []byte, out interface{}) error {
+	response := apiResponseWrapper{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if response.Status != 0 {
+		return &APIError{Status: response.Status, Message: response.Error}
+	}
+
+	if out != nil && len(response.Body) > 0 {
+		if err := json.Unmarshal(response.Body, out); err != nil {
+			return fmt.Errorf("failed to unmarshal response body: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // refreshToken updates the refresh token.
 // Thread Safe: NO
 func (c *UserClient) refreshToken(ctx context.Context) error {
@@ -88,7 +175,7 @@ func (c *UserClient) refreshToken(ctx context.Context) error {
 		return fmt.Errorf("failed to unmarshal response body: %s", err)
 	}
 	if reqTokenResp.Status != 0 {
-		return fmt.Errorf("failed with status %d", reqTokenResp.Status)
+		return &APIError{Status: reqTokenResp.Status, Message: reqTokenResp.Error}
 	}
 	reqTokenResp.Body.AccessTokenCreationDate = createdAt
 	reqTokenResp.Body.RefreshTokenCreationDate = createdAt
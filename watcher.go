@@ -0,0 +1,383 @@
+package gowithings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultWatchMinInterval is the interval users with recent activity are polled at when none is provided.
+	DefaultWatchMinInterval = 15 * time.Minute
+	// DefaultWatchMaxInterval is the longest interval inactive users back off to when none is provided.
+	DefaultWatchMaxInterval = 6 * time.Hour
+	// DefaultWatchLookback is how far back the first poll of a user looks when none is provided.
+	DefaultWatchLookback = 24 * time.Hour
+)
+
+// DefaultWatchApplis are the applis polled when none are provided.
+var DefaultWatchApplis = []Appli{AppliWeight, AppliTemperature, AppliPressure, AppliActivity, AppliSleep}
+
+// WatchCursor records how far the data of a user has been polled and when to poll it next.
+type WatchCursor struct {
+	// LastUpdate is the time of the last successful poll of each appli. It is the server time of the poll when the
+	// API provides one and the local time otherwise.
+	LastUpdate map[Appli]time.Time
+	// Interval is the current poll interval of the user.
+	Interval time.Duration
+	// NextPoll is the time the user is due to be polled.
+	NextPoll time.Time
+}
+
+// CursorStore loads and saves the cursors of the users polled by a watcher. LoadCursor returns a zero cursor for
+// users that were never polled.
+type CursorStore interface {
+	LoadCursor(ctx context.Context, userID int64) (WatchCursor, error)
+	SaveCursor(ctx context.Context, userID int64, cursor WatchCursor) error
+}
+
+// WatcherConfig defines the configuration of a new watcher.
+type WatcherConfig struct {
+	// Client is used to create the user clients.
+	Client *Client
+	// Tokens resolves the token of the users polled.
+	Tokens TokenStore
+	// Cursors persists the poll state of the users so a restarted watcher resumes where it stopped.
+	Cursors CursorStore
+	// Applis are the kinds of data polled. Defaults to DefaultWatchApplis. Only the applis in DefaultWatchApplis can
+	// be polled since the others have no last update filter.
+	Applis []Appli
+	// MinInterval is the poll interval of users with new data. Defaults to DefaultWatchMinInterval.
+	MinInterval time.Duration
+	// MaxInterval is the poll interval users without new data back off to. Defaults to DefaultWatchMaxInterval.
+	MaxInterval time.Duration
+	// Lookback is how far back the first poll of a user looks. Defaults to DefaultWatchLookback.
+	Lookback time.Duration
+	// Buffer is the number of events that can wait to be received from the events channel.
+	Buffer int
+	// OnError, when set, is called when a user could not be polled.
+	OnError func(userID int64, err error)
+}
+
+// Watcher polls the data of users and emits a notification for every change found, as Withings would with a
+// subscription. It is a fallback for deployments that cannot receive notifications. Users with new data are polled
+// every MinInterval and the interval doubles after each poll without new data, up to MaxInterval. Polls that fail
+// without finding new data keep the current interval.
+type Watcher struct {
+	config WatcherConfig
+	events chan Notification
+	users  map[int64]time.Time
+	wake   chan struct{}
+	sync.Mutex
+}
+
+// NewWatcher creates a new watcher based on the configuration provided.
+func NewWatcher(config WatcherConfig) *Watcher {
+	if len(config.Applis) == 0 {
+		config.Applis = DefaultWatchApplis
+	}
+	if config.MinInterval <= 0 {
+		config.MinInterval = DefaultWatchMinInterval
+	}
+	if config.MaxInterval < config.MinInterval {
+		config.MaxInterval = DefaultWatchMaxInterval
+		if config.MaxInterval < config.MinInterval {
+			config.MaxInterval = config.MinInterval
+		}
+	}
+	if config.Lookback <= 0 {
+		config.Lookback = DefaultWatchLookback
+	}
+
+	return &Watcher{
+		config: config,
+		events: make(chan Notification, config.Buffer),
+		users:  make(map[int64]time.Time),
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// Events returns the channel the notifications are emitted on. It is closed when Run returns.
+func (w *Watcher) Events() <-chan Notification {
+	return w.events
+}
+
+// Watch registers the user to be polled. The user is polled as soon as its cursor allows it.
+// Thread Safe: YES
+func (w *Watcher) Watch(userID int64) {
+	w.Lock()
+	if _, ok := w.users[userID]; !ok {
+		w.users[userID] = time.Time{}
+	}
+	w.Unlock()
+	w.notify()
+}
+
+// Unwatch stops polling the user.
+// Thread Safe: YES
+func (w *Watcher) Unwatch(userID int64) {
+	w.Lock()
+	delete(w.users, userID)
+	w.Unlock()
+}
+
+// notify wakes up Run so it picks up a change of the users.
+func (w *Watcher) notify() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// next returns the user due to be polled the soonest.
+// Thread Safe: YES
+func (w *Watcher) next() (int64, time.Time, bool) {
+	w.Lock()
+	defer w.Unlock()
+
+	var (
+		userID int64
+		due    time.Time
+		found  bool
+	)
+	for id, at := range w.users {
+		if !found || at.Before(due) {
+			userID, due, found = id, at, true
+		}
+	}
+	return userID, due, found
+}
+
+// schedule sets the next poll of the user if it is still watched.
+// Thread Safe: YES
+func (w *Watcher) schedule(userID int64, at time.Time) {
+	w.Lock()
+	defer w.Unlock()
+
+	if _, ok := w.users[userID]; ok {
+		w.users[userID] = at
+	}
+}
+
+// Run polls the watched users until the context is done, then closes the events channel and returns the error of
+// the context. Run must only be called once.
+func (w *Watcher) Run(ctx context.Context) error {
+	defer close(w.events)
+
+	for {
+		userID, due, ok := w.next()
+		if ok && !due.After(time.Now()) {
+			w.poll(ctx, userID)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			continue
+		}
+
+		var timer *time.Timer
+		var fire <-chan time.Time
+		if ok {
+			timer = time.NewTimer(time.Until(due))
+			fire = timer.C
+		}
+		select {
+		case <-ctx.Done():
+		case <-w.wake:
+		case <-fire:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// nextInterval returns the poll interval following the current one, resetting it when new data was found and
+// doubling it otherwise.
+func (w *Watcher) nextInterval(current time.Duration, changed bool) time.Duration {
+	if changed || current < w.config.MinInterval {
+		return w.config.MinInterval
+	}
+	if current *= 2; current > w.config.MaxInterval {
+		return w.config.MaxInterval
+	}
+	return current
+}
+
+// poll polls the user if its cursor is due, emits the changes found and saves the cursor once they are received.
+// Errors are reported to OnError.
+func (w *Watcher) poll(ctx context.Context, userID int64) {
+	now := time.Now()
+	report := func(err error) {
+		if err != nil && w.config.OnError != nil {
+			w.config.OnError(userID, err)
+		}
+	}
+
+	if w.config.Client == nil || w.config.Tokens == nil || w.config.Cursors == nil {
+		report(errors.New("watcher requires a client, a token store and a cursor store"))
+		w.schedule(userID, now.Add(w.config.MaxInterval))
+		return
+	}
+
+	cursor, err := w.config.Cursors.LoadCursor(ctx, userID)
+	if err != nil {
+		report(fmt.Errorf("failed to load cursor of user %d: %w", userID, err))
+		w.schedule(userID, now.Add(w.config.MinInterval))
+		return
+	}
+	if cursor.NextPoll.After(now) {
+		w.schedule(userID, cursor.NextPoll)
+		return
+	}
+
+	events, err := w.check(ctx, userID, &cursor, now)
+	report(err)
+
+	if err != nil && len(events) == 0 {
+		// A failed poll says nothing about the activity of the user so it must not back off.
+		cursor.Interval = max(cursor.Interval, w.config.MinInterval)
+	} else {
+		cursor.Interval = w.nextInterval(cursor.Interval, len(events) > 0)
+	}
+	cursor.NextPoll = now.Add(cursor.Interval)
+	w.schedule(userID, cursor.NextPoll)
+
+	// The cursor is only saved once every event is received so none are lost if the watcher stops.
+	for _, n := range events {
+		select {
+		case w.events <- n:
+		case <-ctx.Done():
+			return
+		}
+	}
+	if err := w.config.Cursors.SaveCursor(ctx, userID, cursor); err != nil {
+		report(fmt.Errorf("failed to save cursor of user %d: %w", userID, err))
+	}
+}
+
+// check polls every appli of the user for changes since its cursor, advancing the cursor of the applis polled
+// successfully.
+func (w *Watcher) check(ctx context.Context, userID int64, cursor *WatchCursor, now time.Time) ([]Notification, error) {
+	token, err := w.config.Tokens.LoadToken(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load token of user %d: %w", userID, err)
+	}
+	uc := w.config.Client.NewUserClient(token)
+
+	if cursor.LastUpdate == nil {
+		cursor.LastUpdate = make(map[Appli]time.Time)
+	}
+
+	var (
+		events []Notification
+		errs   []error
+	)
+	for _, appli := range w.config.Applis {
+		since := cursor.LastUpdate[appli]
+		if since.IsZero() {
+			since = now.Add(-w.config.Lookback)
+		}
+
+		found, updated, err := w.changes(ctx, uc, userID, appli, since)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to poll %s of user %d: %w", appli, userID, err))
+			continue
+		}
+		if updated.IsZero() {
+			updated = now
+		}
+		cursor.LastUpdate[appli] = updated
+		events = append(events, found...)
+	}
+
+	// Save the token even if a poll failed since it may have been refreshed before failing.
+	if err := saveRefreshedToken(ctx, w.config.Tokens, userID, token, uc); err != nil {
+		errs = append(errs, err)
+	}
+
+	return events, errors.Join(errs...)
+}
+
+// changes returns a notification for the data of the appli updated since the time provided, along with the server
+// time of the poll or a zero time if the API does not provide it.
+func (w *Watcher) changes(ctx context.Context, uc *UserClient, userID int64, appli Appli, since time.Time) ([]Notification, time.Time, error) {
+	var (
+		events  []Notification
+		updated time.Time
+	)
+
+	switch appli {
+	case AppliWeight, AppliTemperature, AppliPressure:
+		groups, serverTime, err := uc.getAllMeasures(ctx, GetMeasureParam{
+			MeasureTypes: NotificationMeasureTypes[appli],
+			LastUpdate:   since,
+		})
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		if len(groups) == 0 {
+			return nil, serverTime, nil
+		}
+		updated = serverTime
+		start, end := groups[0].Date, groups[0].Date
+		for _, mg := range groups[1:] {
+			start, end = min(start, mg.Date), max(end, mg.Date)
+		}
+		events = append(events, watchNotification(userID, appli, time.Unix(start, 0), time.Unix(end, 0), ""))
+	case AppliActivity:
+		activities, err := uc.GetActivity(ctx, ActivityParam{LastUpdate: since})
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		seen := make(map[string]bool)
+		for _, a := range activities {
+			if !seen[a.Date] {
+				seen[a.Date] = true
+				events = append(events, watchNotification(userID, appli, time.Time{}, time.Time{}, a.Date))
+			}
+		}
+	case AppliSleep:
+		summaries, err := uc.GetSleepSummaries(ctx, SleepSummaryParam{LastUpdate: since})
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		for _, s := range summaries {
+			events = append(events, watchNotification(userID, appli, time.Unix(s.StartDate, 0), time.Unix(s.EndDate, 0), ""))
+		}
+	default:
+		return nil, time.Time{}, fmt.Errorf("appli %s cannot be polled", appli)
+	}
+
+	return events, updated, nil
+}
+
+// watchNotification builds the notification Withings would send for the change, including its form values.
+func watchNotification(userID int64, appli Appli, start, end time.Time, date string) Notification {
+	values := url.Values{}
+	values.Set("userid", strconv.FormatInt(userID, 10))
+	values.Set("appli", strconv.FormatInt(int64(appli), 10))
+	if !start.IsZero() && !end.IsZero() {
+		values.Set("startdate", strconv.FormatInt(start.Unix(), 10))
+		values.Set("enddate", strconv.FormatInt(end.Unix(), 10))
+	}
+	if date != "" {
+		values.Set("date", date)
+	}
+
+	return Notification{
+		UserID:     userID,
+		Appli:      appli,
+		StartDate:  start,
+		EndDate:    end,
+		Date:       date,
+		ReceivedAt: time.Now(),
+		Values:     values,
+	}
+}
@@ -0,0 +1,136 @@
+package gowithings_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/canadyworkshop/gowithings"
+)
+
+// memoryCursors is a cursor store keeping the cursors in memory.
+type memoryCursors struct {
+	cursors map[int64]gowithings.WatchCursor
+	sync.Mutex
+}
+
+func (m *memoryCursors) LoadCursor(ctx context.Context, userID int64) (gowithings.WatchCursor, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.cursors[userID], nil
+}
+
+func (m *memoryCursors) SaveCursor(ctx context.Context, userID int64, cursor gowithings.WatchCursor) error {
+	m.Lock()
+	defer m.Unlock()
+	m.cursors[userID] = cursor
+	return nil
+}
+
+func TestWatcher(t *testing.T) {
+	cursors := &memoryCursors{cursors: map[int64]gowithings.WatchCursor{
+		// User 2 is not due yet and must not be polled.
+		2: {Interval: time.Hour, NextPoll: time.Now().Add(time.Hour)},
+	}}
+	failed := make(chan int64, 10)
+	w := gowithings.NewWatcher(gowithings.WatcherConfig{
+		Client:      gowithings.NewClient(gowithings.Config{}),
+		Tokens:      missingTokens{},
+		Cursors:     cursors,
+		MinInterval: time.Minute,
+		MaxInterval: time.Hour,
+		OnError: func(userID int64, err error) {
+			failed <- userID
+		},
+	})
+	w.Watch(1)
+	w.Watch(2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- w.Run(ctx)
+	}()
+
+	select {
+	case userID := <-failed:
+		if userID != 1 {
+			t.Errorf("polled user %d, want 1", userID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("user 1 was not polled")
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Run returned %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Run did not stop")
+	}
+	if _, ok := <-w.Events(); ok {
+		t.Error("events channel is not closed")
+	}
+
+	select {
+	case userID := <-failed:
+		t.Errorf("user %d polled again", userID)
+	default:
+	}
+
+	cursors.Lock()
+	defer cursors.Unlock()
+	cursor := cursors.cursors[1]
+	if cursor.Interval != time.Minute || cursor.NextPoll.IsZero() {
+		t.Errorf("cursor = %+v, want a one minute interval", cursor)
+	}
+	if len(cursor.LastUpdate) != 0 {
+		t.Errorf("cursor advanced to %v despite failing", cursor.LastUpdate)
+	}
+}
+
+func TestWatcher_FailedPollKeepsInterval(t *testing.T) {
+	cursors := &memoryCursors{cursors: map[int64]gowithings.WatchCursor{
+		1: {Interval: 30 * time.Minute},
+	}}
+	failed := make(chan int64, 10)
+	w := gowithings.NewWatcher(gowithings.WatcherConfig{
+		Client:      gowithings.NewClient(gowithings.Config{}),
+		Tokens:      missingTokens{},
+		Cursors:     cursors,
+		MinInterval: time.Minute,
+		MaxInterval: time.Hour,
+		OnError: func(userID int64, err error) {
+			failed <- userID
+		},
+	})
+	w.Watch(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- w.Run(ctx)
+	}()
+
+	select {
+	case <-failed:
+	case <-time.After(time.Second):
+		t.Fatal("user 1 was not polled")
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not stop")
+	}
+
+	cursors.Lock()
+	defer cursors.Unlock()
+	if interval := cursors.cursors[1].Interval; interval != 30*time.Minute {
+		t.Errorf("Interval = %v, want the 30m interval kept", interval)
+	}
+}
@@ -0,0 +1,302 @@
+package gowithings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// WorkoutCategory is the kind of sport of a workout.
+type WorkoutCategory int64
+
+const (
+	WorkoutCategoryWalk          WorkoutCategory = 1
+	WorkoutCategoryRun           WorkoutCategory = 2
+	WorkoutCategoryHiking        WorkoutCategory = 3
+	WorkoutCategorySkating       WorkoutCategory = 4
+	WorkoutCategoryBMX           WorkoutCategory = 5
+	WorkoutCategoryBicycling     WorkoutCategory = 6
+	WorkoutCategorySwimming      WorkoutCategory = 7
+	WorkoutCategorySurfing       WorkoutCategory = 8
+	WorkoutCategoryKitesurfing   WorkoutCategory = 9
+	WorkoutCategoryWindsurfing   WorkoutCategory = 10
+	WorkoutCategoryBodyboard     WorkoutCategory = 11
+	WorkoutCategoryTennis        WorkoutCategory = 12
+	WorkoutCategoryTableTennis   WorkoutCategory = 13
+	WorkoutCategorySquash        WorkoutCategory = 14
+	WorkoutCategoryBadminton     WorkoutCategory = 15
+	WorkoutCategoryLiftWeights   WorkoutCategory = 16
+	WorkoutCategoryCalisthenics  WorkoutCategory = 17
+	WorkoutCategoryElliptical    WorkoutCategory = 18
+	WorkoutCategoryPilates       WorkoutCategory = 19
+	WorkoutCategoryBasketball    WorkoutCategory = 20
+	WorkoutCategorySoccer        WorkoutCategory = 21
+	WorkoutCategoryFootball      WorkoutCategory = 22
+	WorkoutCategoryRugby         WorkoutCategory = 23
+	WorkoutCategoryVolleyball    WorkoutCategory = 24
+	WorkoutCategoryWaterpolo     WorkoutCategory = 25
+	WorkoutCategoryHorseRiding   WorkoutCategory = 26
+	WorkoutCategoryGolf          WorkoutCategory = 27
+	WorkoutCategoryYoga          WorkoutCategory = 28
+	WorkoutCategoryDancing       WorkoutCategory = 29
+	WorkoutCategoryBoxing        WorkoutCategory = 30
+	WorkoutCategoryFencing       WorkoutCategory = 31
+	WorkoutCategoryWrestling     WorkoutCategory = 32
+	WorkoutCategoryMartialArts   WorkoutCategory = 33
+	WorkoutCategorySkiing        WorkoutCategory = 34
+	WorkoutCategorySnowboarding  WorkoutCategory = 35
+	WorkoutCategoryOther         WorkoutCategory = 36
+	WorkoutCategoryNoActivity    WorkoutCategory = 128
+	WorkoutCategoryRowing        WorkoutCategory = 187
+	WorkoutCategoryZumba         WorkoutCategory = 188
+	WorkoutCategoryBaseball      WorkoutCategory = 191
+	WorkoutCategoryHandball      WorkoutCategory = 192
+	WorkoutCategoryHockey        WorkoutCategory = 193
+	WorkoutCategoryIceHockey     WorkoutCategory = 194
+	WorkoutCategoryClimbing      WorkoutCategory = 195
+	WorkoutCategoryIceSkating    WorkoutCategory = 196
+	WorkoutCategoryMultiSport    WorkoutCategory = 272
+	WorkoutCategoryIndoorWalk    WorkoutCategory = 306
+	WorkoutCategoryIndoorRunning WorkoutCategory = 307
+	WorkoutCategoryIndoorCycling WorkoutCategory = 308
+)
+
+var workoutCategoryNames = map[WorkoutCategory]string{
+	WorkoutCategoryWalk:          "Walk",
+	WorkoutCategoryRun:           "Run",
+	WorkoutCategoryHiking:        "Hiking",
+	WorkoutCategorySkating:       "Skating",
+	WorkoutCategoryBMX:           "BMX",
+	WorkoutCategoryBicycling:     "Bicycling",
+	WorkoutCategorySwimming:      "Swimming",
+	WorkoutCategorySurfing:       "Surfing",
+	WorkoutCategoryKitesurfing:   "Kitesurfing",
+	WorkoutCategoryWindsurfing:   "Windsurfing",
+	WorkoutCategoryBodyboard:     "Bodyboard",
+	WorkoutCategoryTennis:        "Tennis",
+	WorkoutCategoryTableTennis:   "Table tennis",
+	WorkoutCategorySquash:        "Squash",
+	WorkoutCategoryBadminton:     "Badminton",
+	WorkoutCategoryLiftWeights:   "Lift weights",
+	WorkoutCategoryCalisthenics:  "Calisthenics",
+	WorkoutCategoryElliptical:    "Elliptical",
+	WorkoutCategoryPilates:       "Pilates",
+	WorkoutCategoryBasketball:    "Basketball",
+	WorkoutCategorySoccer:        "Soccer",
+	WorkoutCategoryFootball:      "Football",
+	WorkoutCategoryRugby:         "Rugby",
+	WorkoutCategoryVolleyball:    "Volleyball",
+	WorkoutCategoryWaterpolo:     "Waterpolo",
+	WorkoutCategoryHorseRiding:   "Horse riding",
+	WorkoutCategoryGolf:          "Golf",
+	WorkoutCategoryYoga:          "Yoga",
+	WorkoutCategoryDancing:       "Dancing",
+	WorkoutCategoryBoxing:        "Boxing",
+	WorkoutCategoryFencing:       "Fencing",
+	WorkoutCategoryWrestling:     "Wrestling",
+	WorkoutCategoryMartialArts:   "Martial arts",
+	WorkoutCategorySkiing:        "Skiing",
+	WorkoutCategorySnowboarding:  "Snowboarding",
+	WorkoutCategoryOther:         "Other",
+	WorkoutCategoryNoActivity:    "No activity",
+	WorkoutCategoryRowing:        "Rowing",
+	WorkoutCategoryZumba:         "Zumba",
+	WorkoutCategoryBaseball:      "Baseball",
+	WorkoutCategoryHandball:      "Handball",
+	WorkoutCategoryHockey:        "Hockey",
+	WorkoutCategoryIceHockey:     "Ice hockey",
+	WorkoutCategoryClimbing:      "Climbing",
+	WorkoutCategoryIceSkating:    "Ice skating",
+	WorkoutCategoryMultiSport:    "Multi-sport",
+	WorkoutCategoryIndoorWalk:    "Indoor walk",
+	WorkoutCategoryIndoorRunning: "Indoor running",
+	WorkoutCategoryIndoorCycling: "Indoor cycling",
+}
+
+// String returns the name of the category or its numeric value if it is unknown.
+func (c WorkoutCategory) String() string {
+	if name, ok := workoutCategoryNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("WorkoutCategory(%d)", int64(c))
+}
+
+// IsKnown reports whether the category is one of the values documented by Withings.
+func (c WorkoutCategory) IsKnown() bool {
+	_, ok := workoutCategoryNames[c]
+	return ok
+}
+
+// WorkoutField is a field of the workout data that can be requested from the API.
+type WorkoutField string
+
+const (
+	WorkoutFieldCalories          WorkoutField = "calories"
+	WorkoutFieldIntensity         WorkoutField = "intensity"
+	WorkoutFieldManualDistance    WorkoutField = "manual_distance"
+	WorkoutFieldManualCalories    WorkoutField = "manual_calories"
+	WorkoutFieldEffectiveDuration WorkoutField = "effduration"
+	WorkoutFieldHRAverage         WorkoutField = "hr_average"
+	WorkoutFieldHRMin             WorkoutField = "hr_min"
+	WorkoutFieldHRMax             WorkoutField = "hr_max"
+	WorkoutFieldHRZone0           WorkoutField = "hr_zone_0"
+	WorkoutFieldHRZone1           WorkoutField = "hr_zone_1"
+	WorkoutFieldHRZone2           WorkoutField = "hr_zone_2"
+	WorkoutFieldHRZone3           WorkoutField = "hr_zone_3"
+	WorkoutFieldPauseDuration     WorkoutField = "pause_duration"
+	WorkoutFieldAlgoPauseDuration WorkoutField = "algo_pause_duration"
+	WorkoutFieldSPO2Average       WorkoutField = "spo2_average"
+	WorkoutFieldSteps             WorkoutField = "steps"
+	WorkoutFieldDistance          WorkoutField = "distance"
+	WorkoutFieldElevation         WorkoutField = "elevation"
+	WorkoutFieldPoolLaps          WorkoutField = "pool_laps"
+	WorkoutFieldStrokes           WorkoutField = "strokes"
+	WorkoutFieldPoolLength        WorkoutField = "pool_length"
+)
+
+// AllWorkoutFields is every field of the workout data.
+var AllWorkoutFields = []WorkoutField{
+	WorkoutFieldCalories, WorkoutFieldIntensity, WorkoutFieldManualDistance, WorkoutFieldManualCalories,
+	WorkoutFieldEffectiveDuration, WorkoutFieldHRAverage, WorkoutFieldHRMin, WorkoutFieldHRMax, WorkoutFieldHRZone0,
+	WorkoutFieldHRZone1, WorkoutFieldHRZone2, WorkoutFieldHRZone3, WorkoutFieldPauseDuration,
+	WorkoutFieldAlgoPauseDuration, WorkoutFieldSPO2Average, WorkoutFieldSteps, WorkoutFieldDistance,
+	WorkoutFieldElevation, WorkoutFieldPoolLaps, WorkoutFieldStrokes, WorkoutFieldPoolLength,
+}
+
+// WorkoutParam is the parameter needed to specify what workouts to retrieve. Either StartDate and EndDate or
+// LastUpdate must be provided. LastUpdate returns the workouts created or modified since then, which allows
+// incremental fetches. Only the calendar day of StartDate and EndDate is used.
+type WorkoutParam struct {
+	StartDate  time.Time
+	EndDate    time.Time
+	LastUpdate time.Time
+	// Fields selects the data fields to return. All fields are returned if none are provided.
+	Fields []WorkoutField
+	Offset int
+}
+
+// URLEncode encodes the parameter into the form values of a get workouts request.
+func (p WorkoutParam) URLEncode() (string, error) {
+	v := url.Values{}
+	v.Add("action", "getworkouts")
+
+	switch {
+	case !p.LastUpdate.IsZero():
+		v.Add("lastupdate", strconv.FormatInt(p.LastUpdate.Unix(), 10))
+	case !p.StartDate.IsZero() && !p.EndDate.IsZero():
+		v.Add("startdateymd", p.StartDate.Format(time.DateOnly))
+		v.Add("enddateymd", p.EndDate.Format(time.DateOnly))
+	default:
+		return "", errors.New("either a start and end date or a last update must be provided")
+	}
+
+	fields := p.Fields
+	if len(fields) == 0 {
+		fields = AllWorkoutFields
+	}
+	v.Add("data_fields", joinFields(fields))
+
+	if p.Offset > 0 {
+		v.Add("offset", strconv.Itoa(p.Offset))
+	}
+
+	return v.Encode(), nil
+}
+
+// WorkoutResponse is the raw response of a get workouts API request.
+type WorkoutResponse struct {
+	Series []Workout `json:"series"`
+	More   bool      `json:"more"`
+	Offset int       `json:"offset"`
+}
+
+// nextOffset implements pagedResponse.
+func (r WorkoutResponse) nextOffset() (int, bool) {
+	return r.Offset, r.More && r.Offset != 0
+}
+
+// Workout is a single workout session.
+type Workout struct {
+	ID           int64           `json:"id"`
+	Category     WorkoutCategory `json:"category"`
+	Timezone     string          `json:"timezone"`
+	Model        int64           `json:"model"`
+	Attrib       Attrib          `json:"attrib"`
+	StartDate    int64           `json:"startdate"`
+	EndDate      int64           `json:"enddate"`
+	Date         string          `json:"date"`
+	Modified     int64           `json:"modified"`
+	DeviceID     string          `json:"deviceid"`
+	HashDeviceID string          `json:"hash_deviceid"`
+	Data         WorkoutData     `json:"data"`
+}
+
+// WorkoutData are the measured values of a workout. Durations are in seconds, distances in meters and calories in
+// kilocalories.
+type WorkoutData struct {
+	Calories          float64 `json:"calories"`
+	Intensity         int64   `json:"intensity"`
+	ManualDistance    float64 `json:"manual_distance"`
+	ManualCalories    float64 `json:"manual_calories"`
+	EffectiveDuration int64   `json:"effduration"`
+	HRAverage         int64   `json:"hr_average"`
+	HRMin             int64   `json:"hr_min"`
+	HRMax             int64   `json:"hr_max"`
+	HRZone0           int64   `json:"hr_zone_0"`
+	HRZone1           int64   `json:"hr_zone_1"`
+	HRZone2           int64   `json:"hr_zone_2"`
+	HRZone3           int64   `json:"hr_zone_3"`
+	PauseDuration     int64   `json:"pause_duration"`
+	AlgoPauseDuration int64   `json:"algo_pause_duration"`
+	SPO2Average       float64 `json:"spo2_average"`
+	Steps             int64   `json:"steps"`
+	Distance          float64 `json:"distance"`
+	Elevation         float64 `json:"elevation"`
+	PoolLaps          int64   `json:"pool_laps"`
+	Strokes           int64   `json:"strokes"`
+	PoolLength        float64 `json:"pool_length"`
+}
+
+// Location returns the timezone the workout took place in, falling back to UTC.
+func (w Workout) Location() *time.Location {
+	return resolveLocation(w.Timezone)
+}
+
+// Start returns the start of the workout in the timezone it took place in.
+func (w Workout) Start() time.Time {
+	return time.Unix(w.StartDate, 0).In(w.Location())
+}
+
+// End returns the end of the workout in the timezone it took place in.
+func (w Workout) End() time.Time {
+	return time.Unix(w.EndDate, 0).In(w.Location())
+}
+
+// Duration returns the time between the start and end of the workout, including pauses.
+func (w Workout) Duration() time.Duration {
+	return time.Duration(w.EndDate-w.StartDate) * time.Second
+}
+
+// EffectiveDuration returns the time the user was actually active during the workout.
+func (w Workout) EffectiveDuration() time.Duration {
+	return time.Duration(w.Data.EffectiveDuration) * time.Second
+}
+
+// ModifiedAt returns the time the workout was last modified.
+func (w Workout) ModifiedAt() time.Time {
+	return time.Unix(w.Modified, 0).In(w.Location())
+}
+
+// GetWorkouts returns the workouts as specified by the request param, iterating over the offset until every workout
+// is retrieved.
+func (c *UserClient) GetWorkouts(ctx context.Context, param WorkoutParam) ([]Workout, error) {
+	return getAllPages(ctx, c, MeasureV2URL, "get workouts", param.Offset, func(offset int) (string, error) {
+		param.Offset = offset
+		return param.URLEncode()
+	}, func(resp WorkoutResponse) []Workout {
+		return resp.Series
+	})
+}
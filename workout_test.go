@@ -0,0 +1,68 @@
+package gowithings_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/canadyworkshop/gowithings"
+)
+
+func TestWorkoutParam_URLEncode(t *testing.T) {
+	day := time.Date(2024, 3, 10, 8, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		param   gowithings.WorkoutParam
+		want    url.Values
+		wantErr bool
+	}{
+		{
+			name:  "dates",
+			param: gowithings.WorkoutParam{StartDate: day, EndDate: day.AddDate(0, 1, 0), Fields: []gowithings.WorkoutField{gowithings.WorkoutFieldCalories}},
+			want:  url.Values{"action": {"getworkouts"}, "startdateymd": {"2024-03-10"}, "enddateymd": {"2024-04-10"}, "data_fields": {"calories"}},
+		},
+		{
+			name:  "last update",
+			param: gowithings.WorkoutParam{LastUpdate: time.Unix(1700000000, 0), Offset: 2, Fields: []gowithings.WorkoutField{gowithings.WorkoutFieldCalories, gowithings.WorkoutFieldIntensity}},
+			want:  url.Values{"action": {"getworkouts"}, "lastupdate": {"1700000000"}, "offset": {"2"}, "data_fields": {"calories,intensity"}},
+		},
+		{
+			name:    "no range",
+			param:   gowithings.WorkoutParam{EndDate: day},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := tt.param.URLEncode()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got, want := encoded, tt.want.Encode(); got != want {
+				t.Errorf("got %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestUserClient_GetWorkouts(t *testing.T) {
+	api := pagedAPI(map[string]string{
+		"0": `{"series":[{"id":1},{"id":2}],"more":true,"offset":2}`,
+		"2": `{"series":[{"id":3}],"more":false,"offset":0}`,
+	})
+	uc := newTestUserClient(t, api)
+
+	workouts, err := uc.GetWorkouts(context.Background(), gowithings.WorkoutParam{LastUpdate: time.Unix(1700000000, 0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(workouts) != 3 || workouts[2].ID != 3 {
+		t.Errorf("workouts = %+v, want the three workouts of both pages", workouts)
+	}
+	checkOffsets(t, api, "", "2")
+}